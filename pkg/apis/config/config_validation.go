@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the loaded Config sections for consistency so that
+// controllers can fail fast at startup instead of misbehaving at runtime.
+// All section errors are aggregated into the returned error. Nil sections
+// are skipped.
+func (cfg *Config) Validate() error {
+	var errs []error
+	if d := cfg.Defaults; d != nil {
+		if d.DefaultTimeoutMinutes < 0 {
+			errs = append(errs, fmt.Errorf("defaults: %s must not be negative, got %d", defaultTimeoutMinutesKey, d.DefaultTimeoutMinutes))
+		}
+		if d.DefaultMaxMatrixCombinationsCount <= 0 {
+			errs = append(errs, fmt.Errorf("defaults: %s must be positive, got %d", defaultMaxMatrixCombinationsCountKey, d.DefaultMaxMatrixCombinationsCount))
+		}
+		if d.DefaultImagePullBackOffTimeout < 0 {
+			errs = append(errs, fmt.Errorf("defaults: %s must not be negative, got %v", defaultImagePullBackOffTimeout, d.DefaultImagePullBackOffTimeout))
+		}
+	}
+	if ff := cfg.FeatureFlags; ff != nil {
+		if ff.RequireStepActionChecksum && !ff.EnableStepActions {
+			errs = append(errs, fmt.Errorf("feature flags: %s requires %s to be enabled", RequireStepActionChecksum, EnableStepActions))
+		}
+		if ff.EnableParamEnumReferences && !ff.EnableParamEnum {
+			errs = append(errs, fmt.Errorf("feature flags: %s requires %s to be enabled", EnableParamEnumReferences, EnableParamEnum))
+		}
+	}
+	if m := cfg.Metrics; m != nil {
+		switch m.TaskrunLevel {
+		case TaskrunLevelAtTaskrun, TaskrunLevelAtTask, TaskrunLevelAtNS:
+		default:
+			errs = append(errs, fmt.Errorf("metrics: invalid taskrun level %q", m.TaskrunLevel))
+		}
+		switch m.PipelinerunLevel {
+		case PipelinerunLevelAtPipelinerun, PipelinerunLevelAtPipeline, PipelinerunLevelAtNS:
+		default:
+			errs = append(errs, fmt.Errorf("metrics: invalid pipelinerun level %q", m.PipelinerunLevel))
+		}
+		switch m.DurationTaskrunType {
+		case DurationTaskrunTypeHistogram, DurationTaskrunTypeLastValue:
+		default:
+			errs = append(errs, fmt.Errorf("metrics: invalid taskrun duration type %q", m.DurationTaskrunType))
+		}
+		switch m.DurationPipelinerunType {
+		case DurationPipelinerunTypeHistogram, DurationPipelinerunTypeLastValue:
+		default:
+			errs = append(errs, fmt.Errorf("metrics: invalid pipelinerun duration type %q", m.DurationPipelinerunType))
+		}
+	}
+	return errors.Join(errs...)
+}
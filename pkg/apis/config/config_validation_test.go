@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+)
+
+func TestConfigValidate(t *testing.T) {
+	defaults, err := config.NewDefaultsFromMap(map[string]string{})
+	if err != nil {
+		t.Fatalf("NewDefaultsFromMap() = %v", err)
+	}
+	flags, err := config.NewFeatureFlagsFromMap(map[string]string{})
+	if err != nil {
+		t.Fatalf("NewFeatureFlagsFromMap() = %v", err)
+	}
+	valid := &config.Config{
+		Defaults:     defaults,
+		FeatureFlags: flags,
+		Metrics:      config.DefaultMetrics.DeepCopy(),
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() on a default config = %v", err)
+	}
+}
+
+func TestConfigValidateErrors(t *testing.T) {
+	invalid := &config.Config{
+		Defaults: &config.Defaults{
+			DefaultTimeoutMinutes:             -1,
+			DefaultMaxMatrixCombinationsCount: 0,
+		},
+		FeatureFlags: &config.FeatureFlags{
+			RequireStepActionChecksum: true,
+		},
+		Metrics: &config.Metrics{
+			TaskrunLevel:            "bogus",
+			PipelinerunLevel:        config.PipelinerunLevelAtPipeline,
+			DurationTaskrunType:     config.DefaultDurationTaskrunType,
+			DurationPipelinerunType: config.DefaultDurationPipelinerunType,
+		},
+	}
+	err := invalid.Validate()
+	if err == nil {
+		t.Fatalf("Expected an error, got nothing for %v", invalid)
+	}
+	for _, want := range []string{
+		"default-timeout-minutes",
+		"default-max-matrix-combinations-count",
+		"require-stepaction-checksum",
+		`invalid taskrun level "bogus"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error %q does not mention %q", err, want)
+		}
+	}
+}
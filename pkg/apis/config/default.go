@@ -46,6 +46,21 @@ const (
 	DefaultMaxMatrixCombinationsCount = 256
 	// DefaultResolverTypeValue is used when no default resolver type is specified
 	DefaultResolverTypeValue = ""
+	// DefaultMaxStepsPerTask is used when no max steps per task is specified.
+	// Zero means no limit is enforced.
+	DefaultMaxStepsPerTask = 0
+	// DefaultWorkspacePrefixValue is the directory workspaces are mounted under
+	// when a workspace declaration has no explicit mountPath.
+	DefaultWorkspacePrefixValue = "/workspace"
+	// DefaultScriptShebangValue is used when no default script shebang is
+	// specified. The empty string leaves scripts without a shebang untouched.
+	DefaultScriptShebangValue = ""
+	// DefaultMaxDescriptionLength is used when no max description length is
+	// specified. Zero means no limit is enforced.
+	DefaultMaxDescriptionLength = 0
+	// DefaultMaxResultDescriptionLength is used when no max step result
+	// description length is specified. Zero means no limit is enforced.
+	DefaultMaxResultDescriptionLength = 0
 	// default resource requirements, will be applied to all the containers, which has empty resource requirements
 	ResourceRequirementDefaultContainerKey = "default"
 
@@ -62,7 +77,15 @@ const (
 	defaultForbiddenEnv                     = "default-forbidden-env"
 	defaultResolverTypeKey                  = "default-resolver-type"
 	defaultContainerResourceRequirementsKey = "default-container-resource-requirements"
+	defaultSidecarResourceRequirementsKey   = "default-sidecar-resource-requirements"
 	defaultImagePullBackOffTimeout          = "default-imagepullbackoff-timeout"
+	allowedWorkspaceVolumeTypesKey          = "allowed-workspace-volume-types"
+	defaultMaxStepsPerTaskKey               = "default-max-steps-per-task"
+	defaultWorkspacePrefixKey               = "default-workspace-prefix"
+	defaultScriptShebangKey                 = "default-script-shebang"
+	maxDescriptionLengthKey                 = "max-description-length"
+	maxResultDescriptionLengthKey           = "max-result-description-length"
+	defaultTimeoutMinutesPerNamespaceKey    = "default-timeout-minutes-per-namespace"
 )
 
 // DefaultConfig holds all the default configurations for the config.
@@ -82,7 +105,25 @@ type Defaults struct {
 	DefaultForbiddenEnv                  []string
 	DefaultResolverType                  string
 	DefaultContainerResourceRequirements map[string]corev1.ResourceRequirements
+	DefaultSidecarResourceRequirements   *corev1.ResourceRequirements
 	DefaultImagePullBackOffTimeout       time.Duration
+	AllowedWorkspaceVolumeTypes          []string
+	MaxStepsPerTask                      int
+	DefaultWorkspacePrefix               string
+	DefaultScriptShebang                 string
+	MaxDescriptionLength                 int
+	MaxResultDescriptionLength           int
+	DefaultTimeoutMinutesPerNamespace    map[string]int
+}
+
+// TimeoutMinutesForNamespace returns the default timeout in minutes for the
+// given namespace, falling back to DefaultTimeoutMinutes when the namespace
+// has no override.
+func (cfg *Defaults) TimeoutMinutesForNamespace(namespace string) int {
+	if timeout, ok := cfg.DefaultTimeoutMinutesPerNamespace[namespace]; ok {
+		return timeout
+	}
+	return cfg.DefaultTimeoutMinutes
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -114,7 +155,15 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount &&
 		other.DefaultResolverType == cfg.DefaultResolverType &&
 		other.DefaultImagePullBackOffTimeout == cfg.DefaultImagePullBackOffTimeout &&
-		reflect.DeepEqual(other.DefaultForbiddenEnv, cfg.DefaultForbiddenEnv)
+		other.MaxStepsPerTask == cfg.MaxStepsPerTask &&
+		other.DefaultWorkspacePrefix == cfg.DefaultWorkspacePrefix &&
+		other.DefaultScriptShebang == cfg.DefaultScriptShebang &&
+		other.MaxDescriptionLength == cfg.MaxDescriptionLength &&
+		other.MaxResultDescriptionLength == cfg.MaxResultDescriptionLength &&
+		reflect.DeepEqual(other.DefaultForbiddenEnv, cfg.DefaultForbiddenEnv) &&
+		reflect.DeepEqual(other.DefaultTimeoutMinutesPerNamespace, cfg.DefaultTimeoutMinutesPerNamespace) &&
+		reflect.DeepEqual(other.DefaultSidecarResourceRequirements, cfg.DefaultSidecarResourceRequirements) &&
+		reflect.DeepEqual(other.AllowedWorkspaceVolumeTypes, cfg.AllowedWorkspaceVolumeTypes)
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -127,6 +176,11 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		DefaultMaxMatrixCombinationsCount: DefaultMaxMatrixCombinationsCount,
 		DefaultResolverType:               DefaultResolverTypeValue,
 		DefaultImagePullBackOffTimeout:    DefaultImagePullBackOffTimeout,
+		MaxStepsPerTask:                   DefaultMaxStepsPerTask,
+		DefaultWorkspacePrefix:            DefaultWorkspacePrefixValue,
+		DefaultScriptShebang:              DefaultScriptShebangValue,
+		MaxDescriptionLength:              DefaultMaxDescriptionLength,
+		MaxResultDescriptionLength:        DefaultMaxResultDescriptionLength,
 	}
 
 	if defaultTimeoutMin, ok := cfgMap[defaultTimeoutMinutesKey]; ok {
@@ -141,6 +195,14 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultServiceAccount = defaultServiceAccount
 	}
 
+	if defaultWorkspacePrefix, ok := cfgMap[defaultWorkspacePrefixKey]; ok {
+		tc.DefaultWorkspacePrefix = defaultWorkspacePrefix
+	}
+
+	if defaultScriptShebang, ok := cfgMap[defaultScriptShebangKey]; ok {
+		tc.DefaultScriptShebang = defaultScriptShebang
+	}
+
 	if defaultManagedByLabelValue, ok := cfgMap[defaultManagedByLabelValueKey]; ok {
 		tc.DefaultManagedByLabelValue = defaultManagedByLabelValue
 	}
@@ -197,11 +259,65 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultContainerResourceRequirements = resourceRequirementsValue
 	}
 
+	if sidecarResourceRequirementsStringValue, ok := cfgMap[defaultSidecarResourceRequirementsKey]; ok {
+		sidecarResourceRequirementsValue := corev1.ResourceRequirements{}
+		if err := yamlUnmarshal(sidecarResourceRequirementsStringValue, defaultSidecarResourceRequirementsKey, &sidecarResourceRequirementsValue); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", sidecarResourceRequirementsStringValue)
+		}
+		tc.DefaultSidecarResourceRequirements = &sidecarResourceRequirementsValue
+	}
+
+	if allowedTypesString, ok := cfgMap[allowedWorkspaceVolumeTypesKey]; ok {
+		tmpString := sets.NewString()
+		allowedTypes := strings.Split(allowedTypesString, ",")
+		for _, allowedType := range allowedTypes {
+			tmpString.Insert(strings.TrimSpace(allowedType))
+		}
+		tc.AllowedWorkspaceVolumeTypes = tmpString.List()
+	}
+
+	if maxStepsPerTask, ok := cfgMap[defaultMaxStepsPerTaskKey]; ok {
+		maxSteps, err := strconv.ParseInt(maxStepsPerTask, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing tracing config %q", defaultMaxStepsPerTaskKey)
+		}
+		tc.MaxStepsPerTask = int(maxSteps)
+	}
+
+	if maxDescriptionLength, ok := cfgMap[maxDescriptionLengthKey]; ok {
+		maxLength, err := strconv.ParseInt(maxDescriptionLength, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing tracing config %q", maxDescriptionLengthKey)
+		}
+		tc.MaxDescriptionLength = int(maxLength)
+	}
+
+	if maxResultDescriptionLength, ok := cfgMap[maxResultDescriptionLengthKey]; ok {
+		maxLength, err := strconv.ParseInt(maxResultDescriptionLength, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing tracing config %q", maxResultDescriptionLengthKey)
+		}
+		tc.MaxResultDescriptionLength = int(maxLength)
+	}
+
+	if timeoutsPerNamespaceStringValue, ok := cfgMap[defaultTimeoutMinutesPerNamespaceKey]; ok {
+		timeoutsPerNamespaceValue := make(map[string]int)
+		if err := yamlUnmarshal(timeoutsPerNamespaceStringValue, defaultTimeoutMinutesPerNamespaceKey, &timeoutsPerNamespaceValue); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", timeoutsPerNamespaceStringValue)
+		}
+		tc.DefaultTimeoutMinutesPerNamespace = timeoutsPerNamespaceValue
+	}
+
 	if defaultImagePullBackOff, ok := cfgMap[defaultImagePullBackOffTimeout]; ok {
 		timeout, err := time.ParseDuration(defaultImagePullBackOff)
 		if err != nil {
 			return nil, fmt.Errorf("failed parsing tracing config %q", defaultImagePullBackOffTimeout)
 		}
+		// An explicit "0s" is equivalent to leaving the key unset and disables
+		// the imagePullBackOff timeout; negative durations are rejected.
+		if timeout < 0 {
+			return nil, fmt.Errorf("%q must be a non-negative duration but is %q", defaultImagePullBackOffTimeout, defaultImagePullBackOff)
+		}
 		tc.DefaultImagePullBackOffTimeout = timeout
 	}
 
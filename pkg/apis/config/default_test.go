@@ -39,6 +39,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 	testCases := []testCase{
 		{
 			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
 				DefaultTimeoutMinutes:             50,
 				DefaultServiceAccount:             "tekton",
 				DefaultManagedByLabelValue:        "something-else",
@@ -50,6 +51,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 		},
 		{
 			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:     "/workspace",
 				DefaultTimeoutMinutes:      50,
 				DefaultServiceAccount:      "tekton",
 				DefaultManagedByLabelValue: config.DefaultManagedByLabelValue,
@@ -74,6 +76,20 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 		}, {
 			expectedError: true,
 			fileName:      "config-defaults-imagepullbackoff-timeout-err",
+		}, {
+			expectedError: true,
+			fileName:      "config-defaults-imagepullbackoff-timeout-negative",
+		}, {
+			expectedError: false,
+			fileName:      "config-defaults-imagepullbackoff-timeout-zero",
+			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        "tekton-pipelines",
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultImagePullBackOffTimeout:    0,
+			},
 		},
 		// Previously the yaml package did not support UnmarshalStrict, though
 		// it's supported now however it may introduce incompatibility, so we decide
@@ -82,6 +98,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 			expectedError: false,
 			fileName:      "config-defaults-pod-template-err",
 			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
 				DefaultTimeoutMinutes:             50,
 				DefaultServiceAccount:             "tekton",
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
@@ -94,6 +111,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 			expectedError: false,
 			fileName:      "config-defaults-aa-pod-template-err",
 			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
 				DefaultTimeoutMinutes:             50,
 				DefaultServiceAccount:             "tekton",
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
@@ -110,6 +128,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 			expectedError: false,
 			fileName:      "config-defaults-matrix",
 			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
 				DefaultMaxMatrixCombinationsCount: 1024,
 				DefaultTimeoutMinutes:             60,
 				DefaultServiceAccount:             "default",
@@ -121,6 +140,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 			expectedError: false,
 			fileName:      "config-defaults-forbidden-env",
 			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
 				DefaultTimeoutMinutes:             50,
 				DefaultServiceAccount:             "tekton",
 				DefaultMaxMatrixCombinationsCount: 256,
@@ -129,10 +149,75 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultImagePullBackOffTimeout:    time.Duration(15) * time.Second,
 			},
 		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-allowed-workspace-volume-types",
+			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        "tekton-pipelines",
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultImagePullBackOffTimeout:    0,
+				AllowedWorkspaceVolumeTypes:       []string{"configMap", "persistentVolumeClaim", "secret"},
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-max-steps-per-task",
+			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        "tekton-pipelines",
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultImagePullBackOffTimeout:    0,
+				MaxStepsPerTask:                   50,
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-workspace-prefix",
+			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/tekton/workspaces",
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        "tekton-pipelines",
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultImagePullBackOffTimeout:    0,
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-script-shebang",
+			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
+				DefaultScriptShebang:              "#!/usr/bin/env bash",
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        "tekton-pipelines",
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultImagePullBackOffTimeout:    0,
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-max-description-length",
+			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        "tekton-pipelines",
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultImagePullBackOffTimeout:    0,
+				MaxDescriptionLength:              512,
+			},
+		},
 		{
 			expectedError: false,
 			fileName:      "config-defaults-container-resource-requirements-empty",
 			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:               "/workspace",
 				DefaultTimeoutMinutes:                60,
 				DefaultServiceAccount:                "default",
 				DefaultManagedByLabelValue:           "tekton-pipelines",
@@ -149,6 +234,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 			expectedError: false,
 			fileName:      "config-defaults-container-resource-requirements-with-values",
 			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
 				DefaultTimeoutMinutes:             60,
 				DefaultServiceAccount:             "default",
 				DefaultManagedByLabelValue:        "tekton-pipelines",
@@ -189,6 +275,57 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				},
 			},
 		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-max-result-description-length",
+			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        "tekton-pipelines",
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultImagePullBackOffTimeout:    0,
+				MaxResultDescriptionLength:        256,
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-sidecar-resource-requirements",
+			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        "tekton-pipelines",
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultSidecarResourceRequirements: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("128Mi"),
+						corev1.ResourceCPU:    resource.MustParse("250m"),
+					},
+				},
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-timeout-minutes-per-namespace",
+			expectedConfig: &config.Defaults{
+				DefaultWorkspacePrefix:            "/workspace",
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        "tekton-pipelines",
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultTimeoutMinutesPerNamespace: map[string]int{
+					"build": 120,
+					"dev":   15,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -210,6 +347,7 @@ func TestNewDefaultsFromEmptyConfigMap(t *testing.T) {
 		DefaultServiceAccount:             "default",
 		DefaultMaxMatrixCombinationsCount: 256,
 		DefaultImagePullBackOffTimeout:    0,
+		DefaultWorkspacePrefix:            "/workspace",
 	}
 	verifyConfigFileWithExpectedConfig(t, DefaultsConfigEmptyName, expectedConfig)
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -108,6 +109,52 @@ const (
 	EnableParamEnum = "enable-param-enum"
 	// EnableConciseResolverSyntax is the flag to enable concise resolver syntax
 	EnableConciseResolverSyntax = "enable-concise-resolver-syntax"
+	// RequireStepActionChecksum is the flag to require steps referencing StepActions to declare an expected checksum
+	RequireStepActionChecksum = "require-stepaction-checksum"
+	// EnableParamEnumReferences is the flag to allow variable references in param enum values
+	EnableParamEnumReferences = "enable-param-enum-references"
+	// EnableParamFormat is the flag to enable semantic format hints on params
+	EnableParamFormat = "enable-param-format"
+	// RequireExplicitCommand is the flag to require steps without a Ref to declare a command or script
+	RequireExplicitCommand = "require-explicit-command"
+	// EnableParamNumber is the flag to enable the number param type
+	EnableParamNumber = "enable-param-number"
+	// EnableParamPattern is the flag to enable regex pattern constraints on params
+	EnableParamPattern = "enable-param-pattern"
+	// PreferStepResults is the flag to warn when steps write legacy task result paths
+	PreferStepResults = "prefer-step-results"
+	// RequirePinnedImages is the flag to require step and sidecar images to be pinned by digest
+	RequirePinnedImages = "require-pinned-images"
+	// AllowStepTaskResultOverlap is the flag to allow a step result to share its
+	// name with a task result instead of treating the collision as an error
+	AllowStepTaskResultOverlap = "allow-step-task-result-overlap"
+	// RequireImageDigestOrTag is the flag to reject step images that are
+	// untagged or use the "latest" tag
+	RequireImageDigestOrTag = "require-image-digest-or-tag"
+	// ForbidReservedResultNames is the flag to reject task result names that
+	// collide with reserved context variable roots
+	ForbidReservedResultNames = "forbid-reserved-result-names"
+	// UnknownVariablesAsWarnings is the flag to downgrade non-existent-variable
+	// validation errors to warnings
+	UnknownVariablesAsWarnings = "unknown-variables-as-warnings"
+	// RequireCompleteObjectDefaults is the flag to require object param
+	// defaults to provide a value for every declared property key
+	RequireCompleteObjectDefaults = "require-complete-object-defaults"
+	// ForbidReservedParamNames is the flag to reject param names that collide
+	// with context variable prefixes such as "context"
+	ForbidReservedParamNames = "forbid-reserved-param-names"
+	// ValidateSecurityContextConflicts is the flag to reject steps whose merged
+	// securityContext sets runAsNonRoot while running as user 0
+	ValidateSecurityContextConflicts = "validate-security-context-conflicts"
+	// ForbidParamResultCollisions is the flag to reject tasks where a param and
+	// a result share a name
+	ForbidParamResultCollisions = "forbid-param-result-collisions"
+	// RequireExplicitStepNames is the flag to reject steps that do not declare
+	// a name instead of auto-naming them
+	RequireExplicitStepNames = "require-explicit-step-names"
+	// RequireDeclaredVolumeMounts is the flag to reject volumeMounts that do
+	// not reference a volume or workspace declared in the TaskSpec
+	RequireDeclaredVolumeMounts = "require-declared-volume-mounts"
 
 	// DisableInlineSpec is the flag to disable embedded spec
 	// in Taskrun or Pipelinerun
@@ -175,6 +222,132 @@ var (
 		Stability: AlphaAPIFields,
 		Enabled:   DefaultAlphaFeatureEnabled,
 	}
+
+	// DefaultRequireStepActionChecksum is the default PerFeatureFlag value for RequireStepActionChecksum
+	DefaultRequireStepActionChecksum = PerFeatureFlag{
+		Name:      RequireStepActionChecksum,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultEnableParamEnumReferences is the default PerFeatureFlag value for EnableParamEnumReferences
+	DefaultEnableParamEnumReferences = PerFeatureFlag{
+		Name:      EnableParamEnumReferences,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultEnableParamFormat is the default PerFeatureFlag value for EnableParamFormat
+	DefaultEnableParamFormat = PerFeatureFlag{
+		Name:      EnableParamFormat,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultRequireExplicitCommand is the default PerFeatureFlag value for RequireExplicitCommand
+	DefaultRequireExplicitCommand = PerFeatureFlag{
+		Name:      RequireExplicitCommand,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultEnableParamNumber is the default PerFeatureFlag value for EnableParamNumber
+	DefaultEnableParamNumber = PerFeatureFlag{
+		Name:      EnableParamNumber,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultEnableParamPattern is the default PerFeatureFlag value for EnableParamPattern
+	DefaultEnableParamPattern = PerFeatureFlag{
+		Name:      EnableParamPattern,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultPreferStepResults is the default PerFeatureFlag value for PreferStepResults
+	DefaultPreferStepResults = PerFeatureFlag{
+		Name:      PreferStepResults,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultRequirePinnedImages is the default PerFeatureFlag value for RequirePinnedImages
+	DefaultRequirePinnedImages = PerFeatureFlag{
+		Name:      RequirePinnedImages,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultAllowStepTaskResultOverlap is the default PerFeatureFlag value for AllowStepTaskResultOverlap
+	DefaultAllowStepTaskResultOverlap = PerFeatureFlag{
+		Name:      AllowStepTaskResultOverlap,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultRequireImageDigestOrTag is the default PerFeatureFlag value for RequireImageDigestOrTag
+	DefaultRequireImageDigestOrTag = PerFeatureFlag{
+		Name:      RequireImageDigestOrTag,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultForbidReservedResultNames is the default PerFeatureFlag value for ForbidReservedResultNames
+	DefaultForbidReservedResultNames = PerFeatureFlag{
+		Name:      ForbidReservedResultNames,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultUnknownVariablesAsWarnings is the default PerFeatureFlag value for UnknownVariablesAsWarnings
+	DefaultUnknownVariablesAsWarnings = PerFeatureFlag{
+		Name:      UnknownVariablesAsWarnings,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultRequireCompleteObjectDefaults is the default PerFeatureFlag value for RequireCompleteObjectDefaults
+	DefaultRequireCompleteObjectDefaults = PerFeatureFlag{
+		Name:      RequireCompleteObjectDefaults,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultForbidReservedParamNames is the default PerFeatureFlag value for ForbidReservedParamNames
+	DefaultForbidReservedParamNames = PerFeatureFlag{
+		Name:      ForbidReservedParamNames,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultValidateSecurityContextConflicts is the default PerFeatureFlag value for ValidateSecurityContextConflicts
+	DefaultValidateSecurityContextConflicts = PerFeatureFlag{
+		Name:      ValidateSecurityContextConflicts,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultForbidParamResultCollisions is the default PerFeatureFlag value for ForbidParamResultCollisions
+	DefaultForbidParamResultCollisions = PerFeatureFlag{
+		Name:      ForbidParamResultCollisions,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultRequireExplicitStepNames is the default PerFeatureFlag value for RequireExplicitStepNames
+	DefaultRequireExplicitStepNames = PerFeatureFlag{
+		Name:      RequireExplicitStepNames,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultRequireDeclaredVolumeMounts is the default PerFeatureFlag value for RequireDeclaredVolumeMounts
+	DefaultRequireDeclaredVolumeMounts = PerFeatureFlag{
+		Name:      RequireDeclaredVolumeMounts,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
 )
 
 // FeatureFlags holds the features configurations
@@ -196,18 +369,36 @@ type FeatureFlags struct {
 	// ignore: skip trusted resources verification when no matching verification policies found
 	// warn: skip trusted resources verification when no matching verification policies found and log a warning
 	// fail: fail the taskrun or pipelines run if no matching verification policies found
-	VerificationNoMatchPolicy   string
-	EnableProvenanceInStatus    bool
-	ResultExtractionMethod      string
-	MaxResultSize               int
-	SetSecurityContext          bool
-	Coschedule                  string
-	EnableCELInWhenExpression   bool
-	EnableStepActions           bool
-	EnableParamEnum             bool
-	EnableArtifacts             bool
-	DisableInlineSpec           string
-	EnableConciseResolverSyntax bool
+	VerificationNoMatchPolicy        string
+	EnableProvenanceInStatus         bool
+	ResultExtractionMethod           string
+	MaxResultSize                    int
+	SetSecurityContext               bool
+	Coschedule                       string
+	EnableCELInWhenExpression        bool
+	EnableStepActions                bool
+	EnableParamEnum                  bool
+	EnableArtifacts                  bool
+	DisableInlineSpec                string
+	EnableConciseResolverSyntax      bool
+	RequireStepActionChecksum        bool
+	EnableParamEnumReferences        bool
+	EnableParamFormat                bool
+	RequireExplicitCommand           bool
+	EnableParamNumber                bool
+	EnableParamPattern               bool
+	PreferStepResults                bool
+	RequirePinnedImages              bool
+	AllowStepTaskResultOverlap       bool
+	RequireImageDigestOrTag          bool
+	ForbidReservedResultNames        bool
+	UnknownVariablesAsWarnings       bool
+	RequireCompleteObjectDefaults    bool
+	ForbidReservedParamNames         bool
+	ValidateSecurityContextConflicts bool
+	ForbidParamResultCollisions      bool
+	RequireExplicitStepNames         bool
+	RequireDeclaredVolumeMounts      bool
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -312,6 +503,60 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setPerFeatureFlag(EnableConciseResolverSyntax, DefaultEnableConciseResolverSyntax, &tc.EnableConciseResolverSyntax); err != nil {
 		return nil, err
 	}
+	if err := setPerFeatureFlag(RequireStepActionChecksum, DefaultRequireStepActionChecksum, &tc.RequireStepActionChecksum); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(EnableParamEnumReferences, DefaultEnableParamEnumReferences, &tc.EnableParamEnumReferences); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(EnableParamFormat, DefaultEnableParamFormat, &tc.EnableParamFormat); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(RequireExplicitCommand, DefaultRequireExplicitCommand, &tc.RequireExplicitCommand); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(EnableParamNumber, DefaultEnableParamNumber, &tc.EnableParamNumber); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(EnableParamPattern, DefaultEnableParamPattern, &tc.EnableParamPattern); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(PreferStepResults, DefaultPreferStepResults, &tc.PreferStepResults); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(RequirePinnedImages, DefaultRequirePinnedImages, &tc.RequirePinnedImages); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(AllowStepTaskResultOverlap, DefaultAllowStepTaskResultOverlap, &tc.AllowStepTaskResultOverlap); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(RequireImageDigestOrTag, DefaultRequireImageDigestOrTag, &tc.RequireImageDigestOrTag); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(ForbidReservedResultNames, DefaultForbidReservedResultNames, &tc.ForbidReservedResultNames); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(UnknownVariablesAsWarnings, DefaultUnknownVariablesAsWarnings, &tc.UnknownVariablesAsWarnings); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(RequireCompleteObjectDefaults, DefaultRequireCompleteObjectDefaults, &tc.RequireCompleteObjectDefaults); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(ForbidReservedParamNames, DefaultForbidReservedParamNames, &tc.ForbidReservedParamNames); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(ValidateSecurityContextConflicts, DefaultValidateSecurityContextConflicts, &tc.ValidateSecurityContextConflicts); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(ForbidParamResultCollisions, DefaultForbidParamResultCollisions, &tc.ForbidParamResultCollisions); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(RequireExplicitStepNames, DefaultRequireExplicitStepNames, &tc.RequireExplicitStepNames); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(RequireDeclaredVolumeMounts, DefaultRequireDeclaredVolumeMounts, &tc.RequireDeclaredVolumeMounts); err != nil {
+		return nil, err
+	}
 
 	return &tc, nil
 }
@@ -438,6 +683,30 @@ func NewFeatureFlagsFromConfigMap(config *corev1.ConfigMap) (*FeatureFlags, erro
 	return NewFeatureFlagsFromMap(config.Data)
 }
 
+// MergeFeatureFlags returns a new FeatureFlags where every field the overlay
+// explicitly sets, i.e. whose value differs from the default feature flags,
+// wins over the base, and all other fields are inherited from the base. A nil
+// base or overlay is treated as the defaults.
+func MergeFeatureFlags(base, overlay *FeatureFlags) *FeatureFlags {
+	defaults, _ := NewFeatureFlagsFromMap(map[string]string{})
+	merged := *defaults
+	if base != nil {
+		merged = *base
+	}
+	if overlay == nil {
+		return &merged
+	}
+	mergedVal := reflect.ValueOf(&merged).Elem()
+	overlayVal := reflect.ValueOf(overlay).Elem()
+	defaultsVal := reflect.ValueOf(defaults).Elem()
+	for i := 0; i < mergedVal.NumField(); i++ {
+		if !reflect.DeepEqual(overlayVal.Field(i).Interface(), defaultsVal.Field(i).Interface()) {
+			mergedVal.Field(i).Set(overlayVal.Field(i))
+		}
+	}
+	return &merged
+}
+
 // GetVerificationNoMatchPolicy returns the "trusted-resources-verification-no-match-policy" value
 func GetVerificationNoMatchPolicy(ctx context.Context) string {
 	return FromContextOrDefaults(ctx).FeatureFlags.VerificationNoMatchPolicy
@@ -74,7 +74,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				EnableProvenanceInStatus:         false,
 				ResultExtractionMethod:           "termination-message",
 				EnableKeepPodOnCancel:            true,
-				MaxResultSize:                    4096,
+				MaxResultSize:                    8192,
 				SetSecurityContext:               true,
 				Coschedule:                       config.CoscheduleDisabled,
 				EnableCELInWhenExpression:        true,
@@ -430,3 +430,34 @@ func verifyConfigFileWithExpectedFeatureFlagsConfig(t *testing.T, fileName strin
 		t.Errorf("NewFeatureFlagsFromConfigMap(actual) = %v", err)
 	}
 }
+
+func TestMergeFeatureFlags(t *testing.T) {
+	base, err := config.NewFeatureFlagsFromMap(map[string]string{
+		"enable-api-fields": "beta",
+		"enable-param-enum": "true",
+	})
+	if err != nil {
+		t.Fatalf("NewFeatureFlagsFromMap(base) = %v", err)
+	}
+	overlay, err := config.NewFeatureFlagsFromMap(map[string]string{
+		"enable-api-fields": "alpha",
+	})
+	if err != nil {
+		t.Fatalf("NewFeatureFlagsFromMap(overlay) = %v", err)
+	}
+
+	merged := config.MergeFeatureFlags(base, overlay)
+	if merged.EnableAPIFields != "alpha" {
+		t.Errorf("MergeFeatureFlags() EnableAPIFields = %q, want %q from overlay", merged.EnableAPIFields, "alpha")
+	}
+	if !merged.EnableParamEnum {
+		t.Errorf("MergeFeatureFlags() EnableParamEnum = false, want true inherited from base")
+	}
+
+	if d := cmp.Diff(base, config.MergeFeatureFlags(base, nil)); d != "" {
+		t.Errorf("MergeFeatureFlags() with nil overlay diff %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(overlay, config.MergeFeatureFlags(nil, overlay)); d != "" {
+		t.Errorf("MergeFeatureFlags() with nil base diff %s", diff.PrintWantGot(d))
+	}
+}
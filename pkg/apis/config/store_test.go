@@ -66,6 +66,45 @@ func TestStoreLoadWithContext(t *testing.T) {
 	}
 }
 
+func TestStoreLoadWithContextSidecarResourceRequirements(t *testing.T) {
+	defaultConfig := test.ConfigMapFromTestFile(t, "config-defaults-sidecar-resource-requirements")
+
+	expectedDefaults, _ := config.NewDefaultsFromConfigMap(defaultConfig)
+
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(defaultConfig)
+
+	cfg := config.FromContext(store.ToContext(context.Background()))
+
+	if cfg.Defaults.DefaultSidecarResourceRequirements == nil {
+		t.Fatalf("Expected sidecar resource requirements to be set, got nil")
+	}
+	if d := cmp.Diff(expectedDefaults, cfg.Defaults); d != "" {
+		t.Errorf("Unexpected defaults %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestStoreLoadWithContextTimeoutMinutesPerNamespace(t *testing.T) {
+	defaultConfig := test.ConfigMapFromTestFile(t, "config-defaults-timeout-minutes-per-namespace")
+
+	expectedDefaults, _ := config.NewDefaultsFromConfigMap(defaultConfig)
+
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(defaultConfig)
+
+	cfg := config.FromContext(store.ToContext(context.Background()))
+
+	if d := cmp.Diff(expectedDefaults, cfg.Defaults); d != "" {
+		t.Errorf("Unexpected defaults %s", diff.PrintWantGot(d))
+	}
+	if got := cfg.Defaults.TimeoutMinutesForNamespace("build"); got != 120 {
+		t.Errorf("TimeoutMinutesForNamespace(build) = %d, want 120", got)
+	}
+	if got := cfg.Defaults.TimeoutMinutesForNamespace("other"); got != 60 {
+		t.Errorf("TimeoutMinutesForNamespace(other) = %d, want 60", got)
+	}
+}
+
 func TestStoreLoadWithContext_Empty(t *testing.T) {
 	want := &config.Config{
 		Defaults:     config.DefaultConfig.DeepCopy(),
@@ -51,6 +51,23 @@ func (in *Defaults) DeepCopyInto(out *Defaults) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.DefaultSidecarResourceRequirements != nil {
+		in, out := &in.DefaultSidecarResourceRequirements, &out.DefaultSidecarResourceRequirements
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedWorkspaceVolumeTypes != nil {
+		in, out := &in.AllowedWorkspaceVolumeTypes, &out.AllowedWorkspaceVolumeTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultTimeoutMinutesPerNamespace != nil {
+		in, out := &in.DefaultTimeoutMinutesPerNamespace, &out.DefaultTimeoutMinutesPerNamespace
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
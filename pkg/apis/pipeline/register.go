@@ -52,6 +52,10 @@ const (
 	// MemberOfLabelKey is used as the label identifier for a PipelineTask
 	// Set to Tasks/Finally depending on the position of the PipelineTask
 	MemberOfLabelKey = GroupName + "/memberOf"
+
+	// DeprecatedAnnotationKey is used as the annotation identifier to mark a
+	// whole resource as deprecated
+	DeprecatedAnnotationKey = GroupName + "/deprecated"
 )
 
 var (
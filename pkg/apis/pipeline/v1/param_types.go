@@ -20,7 +20,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/substitution"
@@ -50,6 +55,11 @@ type ParamSpec struct {
 	// Properties is the JSON Schema properties to support key-value pairs parameter.
 	// +optional
 	Properties map[string]PropertySpec `json:"properties,omitempty"`
+	// PropertyDefaults declares per-key default values for an object param.
+	// Keys omitted from Default fall back to the value declared here; the
+	// fallback values are merged into Default when defaults are applied.
+	// +optional
+	PropertyDefaults map[string]string `json:"propertyDefaults,omitempty"`
 	// Default is the value a parameter takes if no input value is supplied. If
 	// default is set, a Task may be executed without a supplied value for the
 	// parameter.
@@ -59,6 +69,53 @@ type ParamSpec struct {
 	// If Enum is not set, no input validation is performed for the param.
 	// +optional
 	Enum []string `json:"enum,omitempty"`
+	// EnumCaseInsensitive makes Enum values match case-insensitively, both when
+	// checking the default value against the enum and when detecting duplicate
+	// enum values. Defaults to false, keeping enum matching case-sensitive.
+	// +optional
+	EnumCaseInsensitive bool `json:"enumCaseInsensitive,omitempty"`
+	// ElementEnum declares a set of allowed values for the elements of an
+	// array param. Every element of the array default must appear in the list.
+	// If ElementEnum is not set, no element validation is performed.
+	// +optional
+	ElementEnum []string `json:"elementEnum,omitempty"`
+	// Examples are example input values for the param that may be used to
+	// populate documentation such as a catalog entry. Examples are validated
+	// against the param's declared type and constraints.
+	// +optional
+	Examples []string `json:"examples,omitempty"`
+	// Format is a semantic format hint for the param value, similar to the JSON
+	// Schema "format" keyword. The supported formats are "uri", "date-time" and
+	// "email". Default and Enum values are validated against the format.
+	// +optional
+	Format string `json:"format,omitempty"`
+	// Pattern is an RE2 regular expression that the param value must match.
+	// Pattern may only be set on string type params and the default value (if
+	// present) is validated against it.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+	// Requires lists params that must also be provided when a value for this
+	// param is supplied.
+	// +optional
+	Requires []string `json:"requires,omitempty"`
+	// Conflicts lists params that must not be provided when a value for this
+	// param is supplied.
+	// +optional
+	Conflicts []string `json:"conflicts,omitempty"`
+	// DeprecationMessage marks the param as deprecated. When set and the param
+	// is referenced anywhere in the spec, validation emits a warning carrying
+	// this message so consumers can migrate before the param is removed.
+	// +optional
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+	// Group names the group the param belongs to, so that UIs generated from
+	// the spec can bucket related params together. Grouping has no effect on
+	// runtime behavior.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Order is the position of the param within its group, used by generated
+	// UIs to order params. Orders must be unique within a group.
+	// +optional
+	Order *int `json:"order,omitempty"`
 }
 
 // ParamSpecs is a list of ParamSpec
@@ -98,6 +155,28 @@ func (pp *ParamSpec) SetDefaults(context.Context) {
 	default:
 		pp.Type = ParamTypeString
 	}
+
+	pp.setDefaultsFromPropertyDefaults()
+}
+
+// setDefaultsFromPropertyDefaults merges an object param's per-key
+// PropertyDefaults into its Default, filling in only the keys the default
+// does not already provide.
+func (pp *ParamSpec) setDefaultsFromPropertyDefaults() {
+	if pp.Type != ParamTypeObject || len(pp.PropertyDefaults) == 0 {
+		return
+	}
+	if pp.Default == nil {
+		pp.Default = &ParamValue{Type: ParamTypeObject}
+	}
+	if pp.Default.ObjectVal == nil {
+		pp.Default.ObjectVal = map[string]string{}
+	}
+	for key, val := range pp.PropertyDefaults {
+		if _, ok := pp.Default.ObjectVal[key]; !ok {
+			pp.Default.ObjectVal[key] = val
+		}
+	}
 }
 
 // setDefaultsForProperties sets default type for PropertySpec (string) if it's not specified
@@ -136,6 +215,19 @@ func (ps ParamSpecs) SortByType() (ParamSpecs, ParamSpecs, ParamSpecs) {
 	return stringParams, arrayParams, objectParams
 }
 
+// Validate validates the param block on its own, running the name-format,
+// duplicate-name, type, enum and default-type-mismatch checks that
+// TaskSpec.Validate applies. Error messages that list multiple param names
+// report them sorted, so callers can rely on a deterministic message format.
+func (ps ParamSpecs) Validate(ctx context.Context) *apis.FieldError {
+	errs := ValidateParameterTypes(ctx, ps).ViaField("params")
+	errs = errs.Also(ps.ValidateNoDuplicateNames())
+	errs = errs.Also(ps.validateParamEnums(ctx).ViaField("params"))
+	stringParams, arrayParams, objectParams := ps.SortByType()
+	names := sets.NewString(stringParams.GetNames()...).Insert(arrayParams.GetNames()...)
+	return errs.Also(ValidateNameFormat(names, objectParams))
+}
+
 // ValidateNoDuplicateNames returns an error if any of the params have the same name
 func (ps ParamSpecs) ValidateNoDuplicateNames() *apis.FieldError {
 	var errs *apis.FieldError
@@ -146,11 +238,18 @@ func (ps ParamSpecs) ValidateNoDuplicateNames() *apis.FieldError {
 	return errs
 }
 
-// validateParamEnum validates feature flag, duplication and allowed types for Param Enum
+// validateParamEnum validates feature flag, duplication and allowed types for
+// Param Enum, and rejects an explicitly-set empty enum list
 func (ps ParamSpecs) validateParamEnums(ctx context.Context) *apis.FieldError {
 	var errs *apis.FieldError
 	for _, p := range ps {
+		// A nil enum means no enum was declared; an explicitly-set empty list
+		// would never accept any value and is rejected.
+		if p.Enum == nil {
+			continue
+		}
 		if len(p.Enum) == 0 {
+			errs = errs.Also(apis.ErrGeneric("enum must contain at least one value", "").ViaKey(p.Name))
 			continue
 		}
 		if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableParamEnum {
@@ -159,11 +258,29 @@ func (ps ParamSpecs) validateParamEnums(ctx context.Context) *apis.FieldError {
 		if p.Type != ParamTypeString {
 			errs = errs.Also(apis.ErrGeneric("enum can only be set with string type param", "").ViaKey(p.Name))
 		}
-		for dup := range findDups(p.Enum) {
+		enum := p.Enum
+		if p.EnumCaseInsensitive {
+			enum = make([]string, len(p.Enum))
+			for i, e := range p.Enum {
+				enum[i] = strings.ToLower(e)
+			}
+		}
+		for dup := range findDups(enum) {
 			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("parameter enum value %v appears more than once", dup), "").ViaKey(p.Name))
 		}
+		if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableParamEnumReferences {
+			for _, e := range p.Enum {
+				if strings.Contains(e, "$(") {
+					errs = errs.Also(apis.ErrGeneric("variable references not allowed in enum", "").ViaKey(p.Name))
+				}
+			}
+		}
 		if p.Default != nil && p.Default.StringVal != "" {
-			if !slices.Contains(p.Enum, p.Default.StringVal) {
+			defaultValue := p.Default.StringVal
+			if p.EnumCaseInsensitive {
+				defaultValue = strings.ToLower(defaultValue)
+			}
+			if !slices.Contains(enum, defaultValue) {
 				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value %v not in the enum list", p.Default.StringVal), "").ViaKey(p.Name))
 			}
 		}
@@ -171,6 +288,199 @@ func (ps ParamSpecs) validateParamEnums(ctx context.Context) *apis.FieldError {
 	return errs
 }
 
+// validateParamElementEnums validates feature flag, duplication and allowed
+// types for Param ElementEnum, and checks every element of an array param's
+// default against the element enum.
+func (ps ParamSpecs) validateParamElementEnums(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for _, p := range ps {
+		if len(p.ElementEnum) == 0 {
+			continue
+		}
+		if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableParamEnum {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("feature flag `%s` should be set to true to use ElementEnum", config.EnableParamEnum), "").ViaKey(p.Name))
+		}
+		if p.Type != ParamTypeArray {
+			errs = errs.Also(apis.ErrGeneric("elementEnum can only be set with array type param", "").ViaKey(p.Name))
+		}
+		for dup := range findDups(p.ElementEnum) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("parameter enum value %v appears more than once", dup), "").ViaKey(p.Name))
+		}
+		if p.Default != nil {
+			for _, element := range p.Default.ArrayVal {
+				if !slices.Contains(p.ElementEnum, element) {
+					errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default element %v not in the enum list", element), "").ViaKey(p.Name))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// validateParamGroups validates that the orders declared for params are unique
+// within each group. Ungrouped params share the empty group.
+func (ps ParamSpecs) validateParamGroups() *apis.FieldError {
+	var errs *apis.FieldError
+	seen := map[string]sets.Int{}
+	for _, p := range ps {
+		if p.Order == nil {
+			continue
+		}
+		if seen[p.Group] == nil {
+			seen[p.Group] = sets.NewInt()
+		}
+		if seen[p.Group].Has(*p.Order) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("order %d appears more than once in group %q", *p.Order, p.Group), "order").ViaKey(p.Name))
+		}
+		seen[p.Group].Insert(*p.Order)
+	}
+	return errs
+}
+
+// validateParamExamples validates that each declared example satisfies the
+// param's declared type and constraints.
+func (ps ParamSpecs) validateParamExamples() *apis.FieldError {
+	var errs *apis.FieldError
+	for _, p := range ps {
+		if len(p.Examples) == 0 {
+			continue
+		}
+		if p.Type != ParamTypeString {
+			errs = errs.Also(apis.ErrGeneric("examples can only be set with string type param", "examples").ViaKey(p.Name))
+			continue
+		}
+		// A pattern that fails to compile is reported by validateParamPatterns.
+		var re *regexp.Regexp
+		if p.Pattern != "" {
+			re, _ = regexp.Compile(p.Pattern)
+		}
+		for _, example := range p.Examples {
+			if len(p.Enum) > 0 && !slices.Contains(p.Enum, example) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("example value %v not in the enum list", example), "examples").ViaKey(p.Name))
+			}
+			if re != nil && !re.MatchString(example) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("example value %v does not match pattern %v", example, p.Pattern), "examples").ViaKey(p.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// supportedParamFormats are the format hints that can be declared on a param.
+var supportedParamFormats = sets.NewString("uri", "date-time", "email")
+
+// validateParamFormats validates feature flag, allowed formats and that Default
+// and Enum values satisfy the declared format for Param Format hints
+func (ps ParamSpecs) validateParamFormats(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for _, p := range ps {
+		if p.Format == "" {
+			continue
+		}
+		if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableParamFormat {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("feature flag `%s` should be set to true to use Format", config.EnableParamFormat), "").ViaKey(p.Name))
+		}
+		if p.Type != ParamTypeString {
+			errs = errs.Also(apis.ErrGeneric("format can only be set with string type param", "").ViaKey(p.Name))
+			continue
+		}
+		if !supportedParamFormats.Has(p.Format) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("unknown format %q", p.Format), "").ViaKey(p.Name))
+			continue
+		}
+		if p.Default != nil && p.Default.StringVal != "" && !matchesParamFormat(p.Default.StringVal, p.Format) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value %v does not match format %q", p.Default.StringVal, p.Format), "").ViaKey(p.Name))
+		}
+		for _, e := range p.Enum {
+			if !matchesParamFormat(e, p.Format) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param enum value %v does not match format %q", e, p.Format), "").ViaKey(p.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// ValidateParamValues validates the provided param values against the
+// cross-field requirements declared on the ParamSpecs: a provided param must
+// have every param it Requires provided too (explicitly or via a default) and
+// must not be combined with any param it Conflicts with.
+func ValidateParamValues(paramSpecs ParamSpecs, params Params) *apis.FieldError {
+	var errs *apis.FieldError
+	provided := params.ExtractNames()
+	defaulted := sets.NewString()
+	for _, p := range paramSpecs {
+		if p.Default != nil {
+			defaulted.Insert(p.Name)
+		}
+	}
+	for _, p := range paramSpecs {
+		if !provided.Has(p.Name) {
+			continue
+		}
+		for _, required := range p.Requires {
+			if !provided.Has(required) && !defaulted.Has(required) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param %s requires %s", p.Name, required), "").ViaFieldKey("params", p.Name))
+			}
+		}
+		for _, conflicting := range p.Conflicts {
+			if provided.Has(conflicting) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param %s conflicts with %s", p.Name, conflicting), "").ViaFieldKey("params", p.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// validateParamPatterns validates feature flag, allowed types, regex
+// compilation and that the Default value matches the declared Pattern
+func (ps ParamSpecs) validateParamPatterns(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for _, p := range ps {
+		if p.Pattern == "" {
+			continue
+		}
+		if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableParamPattern {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("feature flag `%s` should be set to true to use Pattern", config.EnableParamPattern), "").ViaKey(p.Name))
+		}
+		if p.Type != ParamTypeString {
+			errs = errs.Also(apis.ErrGeneric("pattern can only be set with string type param", "").ViaKey(p.Name))
+			continue
+		}
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("invalid pattern %q: %v", p.Pattern, err), "").ViaKey(p.Name))
+			continue
+		}
+		if p.Default != nil && p.Default.StringVal != "" && !re.MatchString(p.Default.StringVal) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value %v does not match pattern %v", p.Default.StringVal, p.Pattern), "").ViaKey(p.Name))
+		}
+		// An enum that contradicts the pattern would leave no legal inputs, so
+		// every allowed value must itself match.
+		for _, e := range p.Enum {
+			if !re.MatchString(e) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("enum value %v does not match pattern %v", e, p.Pattern), "").ViaKey(p.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// matchesParamFormat reports whether the value satisfies the given format hint.
+func matchesParamFormat(value, format string) bool {
+	switch format {
+	case "uri":
+		u, err := url.Parse(value)
+		return err == nil && u.Scheme != ""
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	case "email":
+		_, err := mail.ParseAddress(value)
+		return err == nil
+	}
+	return false
+}
+
 // findDups returns the duplicate element in the given slice
 func findDups(vals []string) sets.String {
 	seen := sets.String{}
@@ -462,6 +772,170 @@ func extractParamRefsFromContainer(c *corev1.Container) []string {
 	return paramsRefs
 }
 
+// ParamReference describes a single $(params...) reference found in a
+// TaskSpec, carrying the referenced param name, the object key or array index
+// if one is used, and the field path where the reference appears.
+type ParamReference struct {
+	// Name is the name of the referenced param.
+	Name string
+	// Key is the object key for references such as $(params.myObject.key).
+	Key string
+	// Index is the array index for references such as $(params.myArray[1]),
+	// or nil when the reference does not index into an array.
+	Index *int
+	// Path is the field path where the reference appears, e.g. "steps[0].args[1]".
+	Path string
+}
+
+// paramReferenceRegex matches dot-notation param references, capturing the
+// param name, an optional object key and an optional array index or star.
+var paramReferenceRegex = regexp.MustCompile(`\$\(params\.([-_a-zA-Z0-9]+)(?:\.([-_a-zA-Z0-9]+))?(?:\[([0-9]+|\*)\])?\)`)
+
+// paramRefLocation is a single field value that may contain param references,
+// together with the path of the field that holds it.
+type paramRefLocation struct {
+	value string
+	path  string
+}
+
+// ExtractParamReferences returns every $(params...) reference in the TaskSpec,
+// scanning the same fields of steps, stepTemplate, sidecars, volumes and
+// workspaces as the array-index reference validation. It is exported so
+// external tooling can reuse the canonical reference extraction.
+func ExtractParamReferences(ts *TaskSpec) []ParamReference {
+	locations := []paramRefLocation{}
+	for i, step := range ts.Steps {
+		base := fmt.Sprintf("steps[%d]", i)
+		locations = append(locations, paramRefLocation{step.Script, base + ".script"})
+		locations = append(locations, paramRefLocationsFromContainer(step.ToK8sContainer(), base)...)
+	}
+	if ts.StepTemplate != nil {
+		locations = append(locations, paramRefLocationsFromContainer(ts.StepTemplate.ToK8sContainer(), "stepTemplate")...)
+	}
+	for i, sc := range ts.Sidecars {
+		base := fmt.Sprintf("sidecars[%d]", i)
+		locations = append(locations, paramRefLocation{sc.Script, base + ".script"})
+		locations = append(locations, paramRefLocationsFromContainer(sc.ToK8sContainer(), base)...)
+	}
+	locations = append(locations, paramRefLocationsFromVolumes(ts.Volumes)...)
+	for i, w := range ts.Workspaces {
+		locations = append(locations, paramRefLocation{w.MountPath, fmt.Sprintf("workspaces[%d].mountPath", i)})
+	}
+
+	refs := []ParamReference{}
+	for _, loc := range locations {
+		for _, m := range paramReferenceRegex.FindAllStringSubmatch(loc.value, -1) {
+			ref := ParamReference{Name: m[1], Key: m[2], Path: loc.path}
+			if m[3] != "" && m[3] != "*" {
+				idx, err := strconv.Atoi(m[3])
+				if err == nil {
+					ref.Index = &idx
+				}
+			}
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// paramRefLocationsFromContainer mirrors extractParamRefsFromContainer but
+// keeps track of the field path each value comes from.
+func paramRefLocationsFromContainer(c *corev1.Container, base string) []paramRefLocation {
+	locations := []paramRefLocation{
+		{c.Name, base + ".name"},
+		{c.Image, base + ".image"},
+		{string(c.ImagePullPolicy), base + ".imagePullPolicy"},
+		{c.WorkingDir, base + ".workingDir"},
+	}
+	for i, a := range c.Args {
+		locations = append(locations, paramRefLocation{a, fmt.Sprintf("%s.args[%d]", base, i)})
+	}
+	for i, cmd := range c.Command {
+		locations = append(locations, paramRefLocation{cmd, fmt.Sprintf("%s.command[%d]", base, i)})
+	}
+	for i, e := range c.Env {
+		envBase := fmt.Sprintf("%s.env[%d]", base, i)
+		locations = append(locations, paramRefLocation{e.Value, envBase + ".value"})
+		if e.ValueFrom != nil {
+			if e.ValueFrom.SecretKeyRef != nil {
+				locations = append(locations, paramRefLocation{e.ValueFrom.SecretKeyRef.LocalObjectReference.Name, envBase + ".valueFrom.secretKeyRef.name"})
+				locations = append(locations, paramRefLocation{e.ValueFrom.SecretKeyRef.Key, envBase + ".valueFrom.secretKeyRef.key"})
+			}
+			if e.ValueFrom.ConfigMapKeyRef != nil {
+				locations = append(locations, paramRefLocation{e.ValueFrom.ConfigMapKeyRef.LocalObjectReference.Name, envBase + ".valueFrom.configMapKeyRef.name"})
+				locations = append(locations, paramRefLocation{e.ValueFrom.ConfigMapKeyRef.Key, envBase + ".valueFrom.configMapKeyRef.key"})
+			}
+		}
+	}
+	for i, e := range c.EnvFrom {
+		envFromBase := fmt.Sprintf("%s.envFrom[%d]", base, i)
+		locations = append(locations, paramRefLocation{e.Prefix, envFromBase + ".prefix"})
+		if e.ConfigMapRef != nil {
+			locations = append(locations, paramRefLocation{e.ConfigMapRef.LocalObjectReference.Name, envFromBase + ".configMapRef.name"})
+		}
+		if e.SecretRef != nil {
+			locations = append(locations, paramRefLocation{e.SecretRef.LocalObjectReference.Name, envFromBase + ".secretRef.name"})
+		}
+	}
+	for i, v := range c.VolumeMounts {
+		vmBase := fmt.Sprintf("%s.volumeMounts[%d]", base, i)
+		locations = append(locations, paramRefLocation{v.Name, vmBase + ".name"})
+		locations = append(locations, paramRefLocation{v.MountPath, vmBase + ".mountPath"})
+		locations = append(locations, paramRefLocation{v.SubPath, vmBase + ".subPath"})
+	}
+	return locations
+}
+
+// paramRefLocationsFromVolumes mirrors extractParamRefsFromVolumes but keeps
+// track of the field path each value comes from.
+func paramRefLocationsFromVolumes(volumes []corev1.Volume) []paramRefLocation {
+	locations := []paramRefLocation{}
+	for i, v := range volumes {
+		base := fmt.Sprintf("volumes[%d]", i)
+		locations = append(locations, paramRefLocation{v.Name, base + ".name"})
+		if v.ConfigMap != nil {
+			locations = append(locations, paramRefLocation{v.ConfigMap.Name, base + ".configMap.name"})
+			for j, item := range v.ConfigMap.Items {
+				locations = append(locations, paramRefLocation{item.Key, fmt.Sprintf("%s.configMap.items[%d].key", base, j)})
+				locations = append(locations, paramRefLocation{item.Path, fmt.Sprintf("%s.configMap.items[%d].path", base, j)})
+			}
+		}
+		if v.Secret != nil {
+			locations = append(locations, paramRefLocation{v.Secret.SecretName, base + ".secret.secretName"})
+			for j, item := range v.Secret.Items {
+				locations = append(locations, paramRefLocation{item.Key, fmt.Sprintf("%s.secret.items[%d].key", base, j)})
+				locations = append(locations, paramRefLocation{item.Path, fmt.Sprintf("%s.secret.items[%d].path", base, j)})
+			}
+		}
+		if v.PersistentVolumeClaim != nil {
+			locations = append(locations, paramRefLocation{v.PersistentVolumeClaim.ClaimName, base + ".persistentVolumeClaim.claimName"})
+		}
+		if v.Projected != nil {
+			for j, s := range v.Projected.Sources {
+				sourceBase := fmt.Sprintf("%s.projected.sources[%d]", base, j)
+				if s.ConfigMap != nil {
+					locations = append(locations, paramRefLocation{s.ConfigMap.Name, sourceBase + ".configMap.name"})
+				}
+				if s.Secret != nil {
+					locations = append(locations, paramRefLocation{s.Secret.Name, sourceBase + ".secret.name"})
+				}
+				if s.ServiceAccountToken != nil {
+					locations = append(locations, paramRefLocation{s.ServiceAccountToken.Audience, sourceBase + ".serviceAccountToken.audience"})
+				}
+			}
+		}
+		if v.CSI != nil {
+			if v.CSI.NodePublishSecretRef != nil {
+				locations = append(locations, paramRefLocation{v.CSI.NodePublishSecretRef.Name, base + ".csi.nodePublishSecretRef.name"})
+			}
+			for key, value := range v.CSI.VolumeAttributes {
+				locations = append(locations, paramRefLocation{value, fmt.Sprintf("%s.csi.volumeAttributes[%s]", base, key)})
+			}
+		}
+	}
+	return locations
+}
+
 // ParamType indicates the type of an input parameter;
 // Used to distinguish between a single string and an array of strings.
 type ParamType string
@@ -471,10 +945,11 @@ const (
 	ParamTypeString ParamType = "string"
 	ParamTypeArray  ParamType = "array"
 	ParamTypeObject ParamType = "object"
+	ParamTypeNumber ParamType = "number"
 )
 
 // AllParamTypes can be used for ParamType validation.
-var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject}
+var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject, ParamTypeNumber}
 
 // ParamValues is modeled after IntOrString in kubernetes/apimachinery:
 
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
@@ -148,6 +150,44 @@ func TestParamSpec_SetDefaults(t *testing.T) {
 				ObjectVal: map[string]string{"url": "test", "path": "test"},
 			},
 		},
+	}, {
+		name: "property defaults fill in keys omitted from default",
+		before: &v1.ParamSpec{
+			Name:             "parametername",
+			Properties:       map[string]v1.PropertySpec{"url": {Type: "string"}, "path": {Type: "string"}},
+			PropertyDefaults: map[string]string{"url": "fallback", "path": "fallback"},
+			Default: &v1.ParamValue{
+				Type:      v1.ParamTypeObject,
+				ObjectVal: map[string]string{"url": "test"},
+			},
+		},
+		defaultsApplied: &v1.ParamSpec{
+			Name:             "parametername",
+			Type:             v1.ParamTypeObject,
+			Properties:       map[string]v1.PropertySpec{"url": {Type: "string"}, "path": {Type: "string"}},
+			PropertyDefaults: map[string]string{"url": "fallback", "path": "fallback"},
+			Default: &v1.ParamValue{
+				Type:      v1.ParamTypeObject,
+				ObjectVal: map[string]string{"url": "test", "path": "fallback"},
+			},
+		},
+	}, {
+		name: "property defaults without a default value",
+		before: &v1.ParamSpec{
+			Name:             "parametername",
+			Properties:       map[string]v1.PropertySpec{"url": {Type: "string"}},
+			PropertyDefaults: map[string]string{"url": "fallback"},
+		},
+		defaultsApplied: &v1.ParamSpec{
+			Name:             "parametername",
+			Type:             v1.ParamTypeObject,
+			Properties:       map[string]v1.PropertySpec{"url": {Type: "string"}},
+			PropertyDefaults: map[string]string{"url": "fallback"},
+			Default: &v1.ParamValue{
+				Type:      v1.ParamTypeObject,
+				ObjectVal: map[string]string{"url": "fallback"},
+			},
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -809,3 +849,209 @@ func TestValidateNoDuplicateNames(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractParamReferences(t *testing.T) {
+	index := 1
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Image:  "$(params.image)",
+			Args:   []string{"build", "$(params.flags[1])"},
+			Script: "echo $(params.myObject.key1)",
+		}},
+		StepTemplate: &v1.StepTemplate{
+			WorkingDir: "$(params.workdir)",
+		},
+		Sidecars: []v1.Sidecar{{
+			Name:    "sc",
+			Image:   "my-image",
+			Command: []string{"$(params.sidecar-cmd)"},
+		}},
+		Volumes: []corev1.Volume{{
+			Name: "secret-volume",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: "$(params.secret-name)"},
+			},
+		}},
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name:      "data",
+			MountPath: "/mnt/$(params.subdir)",
+		}},
+	}
+	want := []v1.ParamReference{{
+		Name: "myObject",
+		Key:  "key1",
+		Path: "steps[0].script",
+	}, {
+		Name: "image",
+		Path: "steps[0].image",
+	}, {
+		Name:  "flags",
+		Index: &index,
+		Path:  "steps[0].args[1]",
+	}, {
+		Name: "workdir",
+		Path: "stepTemplate.workingDir",
+	}, {
+		Name: "sidecar-cmd",
+		Path: "sidecars[0].command[0]",
+	}, {
+		Name: "secret-name",
+		Path: "volumes[0].secret.secretName",
+	}, {
+		Name: "subdir",
+		Path: "workspaces[0].mountPath",
+	}}
+	got := v1.ExtractParamReferences(ts)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ExtractParamReferences() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidateParamValues(t *testing.T) {
+	paramSpecs := v1.ParamSpecs{{
+		Name:     "token",
+		Requires: []string{"user"},
+	}, {
+		Name: "user",
+	}, {
+		Name:      "anonymous",
+		Conflicts: []string{"token"},
+	}, {
+		Name:     "needs-defaulted",
+		Requires: []string{"defaulted"},
+	}, {
+		Name:    "defaulted",
+		Default: v1.NewStructuredValues("value"),
+	}}
+	tcs := []struct {
+		name          string
+		params        v1.Params
+		expectedError *apis.FieldError
+	}{{
+		name: "requirements satisfied",
+		params: v1.Params{{
+			Name: "token", Value: *v1.NewStructuredValues("tkn"),
+		}, {
+			Name: "user", Value: *v1.NewStructuredValues("user"),
+		}},
+	}, {
+		name: "requirement satisfied by default",
+		params: v1.Params{{
+			Name: "needs-defaulted", Value: *v1.NewStructuredValues("x"),
+		}},
+	}, {
+		name: "missing required param",
+		params: v1.Params{{
+			Name: "token", Value: *v1.NewStructuredValues("tkn"),
+		}},
+		expectedError: &apis.FieldError{
+			Message: `param token requires user`,
+			Paths:   []string{"params[token]"},
+		},
+	}, {
+		name: "conflicting params",
+		params: v1.Params{{
+			Name: "anonymous", Value: *v1.NewStructuredValues("true"),
+		}, {
+			Name: "token", Value: *v1.NewStructuredValues("tkn"),
+		}, {
+			Name: "user", Value: *v1.NewStructuredValues("user"),
+		}},
+		expectedError: &apis.FieldError{
+			Message: `param anonymous conflicts with token`,
+			Paths:   []string{"params[anonymous]"},
+		},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v1.ValidateParamValues(paramSpecs, tc.params)
+			if tc.expectedError == nil {
+				if err != nil {
+					t.Errorf("ValidateParamValues() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing")
+			}
+			if d := cmp.Diff(tc.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ValidateParamValues() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParamSpecsValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        v1.ParamSpecs
+		expectedError *apis.FieldError
+	}{{
+		name: "valid params",
+		params: v1.ParamSpecs{{
+			Name: "foo",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "bar",
+			Type: v1.ParamTypeArray,
+		}},
+	}, {
+		name: "invalid names reported sorted",
+		params: v1.ParamSpecs{{
+			Name: "f oo",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "0ab",
+			Type: v1.ParamTypeString,
+		}},
+		expectedError: &apis.FieldError{
+			Message: fmt.Sprintf("The format of following array and string variable names is invalid: %s", []string{"0ab", "f oo"}),
+			Paths:   []string{"params"},
+			Details: "String/Array Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_), and dots (.)\nMust begin with a letter or an underscore (_)",
+		},
+	}, {
+		name: "duplicate param name",
+		params: v1.ParamSpecs{{
+			Name: "foo",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "foo",
+			Type: v1.ParamTypeString,
+		}},
+		expectedError: &apis.FieldError{
+			Message: `parameter appears more than once`,
+			Paths:   []string{"params[foo]"},
+		},
+	}, {
+		name: "default type mismatch",
+		params: v1.ParamSpecs{{
+			Name: "foo",
+			Type: v1.ParamTypeString,
+			Default: &v1.ParamValue{
+				Type:     v1.ParamTypeArray,
+				ArrayVal: []string{"array"},
+			},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `"string" type does not match default value's type: "array"`,
+			Paths:   []string{"params.foo.type", "params.foo.default.type"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate(context.Background())
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("ParamSpecs.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ParamSpecs.Validate() did not return an error for %v", tt.params)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ParamSpecs.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
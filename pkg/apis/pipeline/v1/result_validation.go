@@ -40,9 +40,28 @@ func (tr TaskResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 	case tr.Type != ResultsTypeString:
 		errs = errs.Also(apis.ErrInvalidValue(tr.Type, "type", "type must be string"))
 	}
+	errs = errs.Also(tr.validateMaxSize(ctx))
 	return errs.Also(tr.validateValue(ctx))
 }
 
+// validateMaxSize checks that a declared result max size is non-negative and
+// does not exceed the cluster-wide max-result-size cap.
+func (tr TaskResult) validateMaxSize(ctx context.Context) *apis.FieldError {
+	if tr.MaxSize == nil {
+		return nil
+	}
+	if *tr.MaxSize < 0 {
+		return apis.ErrInvalidValue(*tr.MaxSize, "maxSize", "maxSize must be non-negative")
+	}
+	if ff := config.FromContextOrDefaults(ctx).FeatureFlags; ff != nil && *tr.MaxSize > ff.MaxResultSize {
+		return &apis.FieldError{
+			Message: "result max size exceeds cluster limit",
+			Paths:   []string{"maxSize"},
+		}
+	}
+	return nil
+}
+
 // validateObjectResult validates the object result and check if the Properties is missing
 // for Properties values it will check if the type is string.
 func validateObjectResult(tr TaskResult) (errs *apis.FieldError) {
@@ -117,18 +136,23 @@ func (sr StepResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 		return apis.ErrInvalidKeyName(sr.Name, "name", fmt.Sprintf("Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '%s')", ResultNameFormat))
 	}
 
+	if defaults := config.FromContextOrDefaults(ctx).Defaults; defaults != nil &&
+		defaults.MaxResultDescriptionLength > 0 && len(sr.Description) > defaults.MaxResultDescriptionLength {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result description exceeds %d characters", defaults.MaxResultDescriptionLength), "description"))
+	}
+
 	switch {
 	case sr.Type == ResultsTypeObject:
-		return validateObjectStepResult(sr)
+		return errs.Also(validateObjectStepResult(sr))
 	case sr.Type == ResultsTypeArray:
-		return nil
+		return errs
 	// The Type is string by default if it is empty.
 	case sr.Type == "":
-		return nil
+		return errs
 	case sr.Type == ResultsTypeString:
-		return nil
+		return errs
 	default:
-		return apis.ErrInvalidValue(sr.Type, "type", fmt.Sprintf("invalid type %s", sr.Type))
+		return errs.Also(apis.ErrInvalidValue(sr.Type, "type", fmt.Sprintf("invalid type %s", sr.Type)))
 	}
 }
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -440,3 +441,110 @@ func TestStepResultsValidateError(t *testing.T) {
 		})
 	}
 }
+
+func TestStepResultsValidateMaxDescriptionLength(t *testing.T) {
+	tests := []struct {
+		name                       string
+		maxResultDescriptionLength int
+		description                string
+		expectedError              *apis.FieldError
+	}{{
+		name:                       "no limit configured",
+		maxResultDescriptionLength: 0,
+		description:                strings.Repeat("x", 1000),
+	}, {
+		name:                       "description at the limit",
+		maxResultDescriptionLength: 10,
+		description:                strings.Repeat("x", 10),
+	}, {
+		name:                       "description over the limit",
+		maxResultDescriptionLength: 10,
+		description:                strings.Repeat("x", 11),
+		expectedError: &apis.FieldError{
+			Message: "result description exceeds 10 characters",
+			Paths:   []string{"description"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(context.Background(), &config.Config{
+				Defaults: &config.Defaults{
+					MaxResultDescriptionLength: tt.maxResultDescriptionLength,
+				},
+			})
+			sr := v1.StepResult{
+				Name:        "MY-RESULT",
+				Description: tt.description,
+			}
+			err := sr.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("StepResult.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", sr)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("StepResult.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskResultValidateMaxSize(t *testing.T) {
+	within := 1024
+	over := 5000
+	negative := -1
+	tests := []struct {
+		name          string
+		Result        v1.TaskResult
+		expectedError *apis.FieldError
+	}{{
+		name: "max size within the cluster limit",
+		Result: v1.TaskResult{
+			Name:    "MY-RESULT",
+			MaxSize: &within,
+		},
+	}, {
+		name: "max size exceeds the cluster limit",
+		Result: v1.TaskResult{
+			Name:    "MY-RESULT",
+			MaxSize: &over,
+		},
+		expectedError: &apis.FieldError{
+			Message: "result max size exceeds cluster limit",
+			Paths:   []string{"maxSize"},
+		},
+	}, {
+		name: "negative max size",
+		Result: v1.TaskResult{
+			Name:    "MY-RESULT",
+			MaxSize: &negative,
+		},
+		expectedError: &apis.FieldError{
+			Message: "invalid value: -1",
+			Paths:   []string{"maxSize"},
+			Details: "maxSize must be non-negative",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			err := tt.Result.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskResult.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", tt.Result)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskResult.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package v1
 
 import (
 	"context"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"knative.dev/pkg/apis"
@@ -33,11 +34,37 @@ func (t *Task) SetDefaults(ctx context.Context) {
 // SetDefaults set any defaults for the task spec
 func (ts *TaskSpec) SetDefaults(ctx context.Context) {
 	cfg := config.FromContextOrDefaults(ctx)
+	defaultShebang := ""
+	if cfg.Defaults != nil {
+		defaultShebang = cfg.Defaults.DefaultScriptShebang
+	}
 	for _, s := range ts.Steps {
 		if s.Ref != nil && s.Ref.Name == "" && s.Ref.Resolver == "" {
 			s.Ref.Resolver = ResolverName(cfg.Defaults.DefaultResolverType)
 		}
 	}
+	for i := range ts.Steps {
+		// A zero step timeout is equivalent to leaving it unset.
+		if ts.Steps[i].Timeout != nil && ts.Steps[i].Timeout.Duration == 0 {
+			ts.Steps[i].Timeout = nil
+		}
+		// Scripts without a shebang line pick up the operator-configured
+		// default interpreter, if one is set.
+		if defaultShebang != "" {
+			script := ts.Steps[i].Script
+			if trimmed := strings.TrimSpace(script); trimmed != "" && !strings.HasPrefix(trimmed, "#!") {
+				ts.Steps[i].Script = defaultShebang + "\n" + script
+			}
+		}
+	}
+	for i := range ts.Sidecars {
+		// A sidecar that sets its own resources keeps them; only fully unset
+		// resources pick up the cluster-wide default.
+		if cfg.Defaults.DefaultSidecarResourceRequirements != nil &&
+			ts.Sidecars[i].ComputeResources.Requests == nil && ts.Sidecars[i].ComputeResources.Limits == nil {
+			ts.Sidecars[i].ComputeResources = *cfg.Defaults.DefaultSidecarResourceRequirements.DeepCopy()
+		}
+	}
 	for i := range ts.Params {
 		ts.Params[i].SetDefaults(ctx)
 	}
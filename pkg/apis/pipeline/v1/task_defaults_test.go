@@ -23,6 +23,8 @@ import (
 	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestTask_SetDefaults(t *testing.T) {
@@ -98,3 +100,145 @@ func TestTask_SetDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestTask_SetDefaultsScriptShebang(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *v1.Task
+		want *v1.Task
+	}{{
+		name: "script without shebang gains the configured one",
+		in: &v1.Task{
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image:  "my-image",
+					Script: "echo hello",
+				}},
+			},
+		},
+		want: &v1.Task{
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image:  "my-image",
+					Script: "#!/usr/bin/env bash\necho hello",
+				}},
+			},
+		},
+	}, {
+		name: "script with its own shebang is not modified",
+		in: &v1.Task{
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image:  "my-image",
+					Script: "#!/usr/bin/env sh\necho hello",
+				}},
+			},
+		},
+		want: &v1.Task{
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image:  "my-image",
+					Script: "#!/usr/bin/env sh\necho hello",
+				}},
+			},
+		},
+	}, {
+		name: "empty script is left alone",
+		in: &v1.Task{
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image: "my-image",
+				}},
+			},
+		},
+		want: &v1.Task{
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image: "my-image",
+				}},
+			},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := cfgtesting.SetDefaults(context.Background(), t, map[string]string{
+				"default-script-shebang": "#!/usr/bin/env bash",
+			})
+			got := tc.in
+			got.SetDefaults(ctx)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("SetDefaults %s", diff.PrintWantGot(d))
+			}
+			// Once the default shebang is applied no missing-shebang warning
+			// should remain.
+			if warnings := got.Spec.ValidateWarnings(ctx); warnings != nil {
+				t.Errorf("ValidateWarnings() = %v, want none", warnings)
+			}
+		})
+	}
+}
+
+func TestTask_SetDefaultsSidecarResources(t *testing.T) {
+	defaultRequirements := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+	}
+	ownRequirements := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+	}
+	tests := []struct {
+		name string
+		in   *v1.Task
+		want *v1.Task
+	}{{
+		name: "sidecar without resources picks up the default",
+		in: &v1.Task{
+			Spec: v1.TaskSpec{
+				Sidecars: []v1.Sidecar{{
+					Name: "sc",
+				}},
+			},
+		},
+		want: &v1.Task{
+			Spec: v1.TaskSpec{
+				Sidecars: []v1.Sidecar{{
+					Name:             "sc",
+					ComputeResources: defaultRequirements,
+				}},
+			},
+		},
+	}, {
+		name: "sidecar with its own resources is not overwritten",
+		in: &v1.Task{
+			Spec: v1.TaskSpec{
+				Sidecars: []v1.Sidecar{{
+					Name:             "sc",
+					ComputeResources: ownRequirements,
+				}},
+			},
+		},
+		want: &v1.Task{
+			Spec: v1.TaskSpec{
+				Sidecars: []v1.Sidecar{{
+					Name:             "sc",
+					ComputeResources: ownRequirements,
+				}},
+			},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := cfgtesting.SetDefaults(context.Background(), t, map[string]string{
+				"default-sidecar-resource-requirements": "requests:\n  memory: \"64Mi\"\n",
+			})
+			got := tc.in
+			got.SetDefaults(ctx)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("SetDefaults %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
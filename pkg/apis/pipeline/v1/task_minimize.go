@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"sort"
+
+	"knative.dev/pkg/apis"
+)
+
+// MinimizeFailingSpec reduces a TaskSpec that fails validation to a smaller
+// spec that still fails with the same error messages, for use in bug reports.
+// It repeatedly tries to drop each param, step and result in declaration
+// order, keeping a removal only when the validation messages are unchanged,
+// so the result is deterministic. A spec that validates cleanly is returned
+// as an unmodified copy.
+func MinimizeFailingSpec(ctx context.Context, spec *TaskSpec) *TaskSpec {
+	original := validationMessages(spec.Validate(ctx))
+	current := spec.DeepCopy()
+	if len(original) == 0 {
+		return current
+	}
+	for changed := true; changed; {
+		changed = false
+		for i := 0; i < len(current.Params); i++ {
+			candidate := current.DeepCopy()
+			candidate.Params = append(candidate.Params[:i], candidate.Params[i+1:]...)
+			if sameValidationMessages(ctx, candidate, original) {
+				current = candidate
+				changed = true
+				i--
+			}
+		}
+		for i := 0; i < len(current.Steps); i++ {
+			candidate := current.DeepCopy()
+			candidate.Steps = append(candidate.Steps[:i], candidate.Steps[i+1:]...)
+			if sameValidationMessages(ctx, candidate, original) {
+				current = candidate
+				changed = true
+				i--
+			}
+		}
+		for i := 0; i < len(current.Results); i++ {
+			candidate := current.DeepCopy()
+			candidate.Results = append(candidate.Results[:i], candidate.Results[i+1:]...)
+			if sameValidationMessages(ctx, candidate, original) {
+				current = candidate
+				changed = true
+				i--
+			}
+		}
+	}
+	if len(current.Params) == 0 {
+		current.Params = nil
+	}
+	if len(current.Results) == 0 {
+		current.Results = nil
+	}
+	return current
+}
+
+func sameValidationMessages(ctx context.Context, candidate *TaskSpec, original []string) bool {
+	messages := validationMessages(candidate.Validate(ctx))
+	if len(messages) != len(original) {
+		return false
+	}
+	for i := range messages {
+		if messages[i] != original[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// validationMessages returns the sorted error messages of a validation
+// result, ignoring field paths so that removals that only shift indices
+// still count as producing the same error.
+func validationMessages(err *apis.FieldError) []string {
+	if err == nil {
+		return nil
+	}
+	var messages []string
+	for _, e := range err.WrappedErrors() {
+		messages = append(messages, e.Message)
+	}
+	sort.Strings(messages)
+	return messages
+}
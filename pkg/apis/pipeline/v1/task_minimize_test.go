@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestMinimizeFailingSpec(t *testing.T) {
+	spec := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{
+			Name: "message",
+			Type: v1.ParamTypeString,
+		}},
+		Steps: []v1.Step{{
+			Name:  "fine",
+			Image: "my-image",
+			Args:  []string{"$(params.message)"},
+		}, {
+			Name:    "broken",
+			Image:   "my-image",
+			Script:  "echo hello",
+			Command: []string{"sh"},
+		}, {
+			Name:  "also-fine",
+			Image: "my-image",
+		}},
+		Results: []v1.TaskResult{{
+			Name: "unrelated",
+		}},
+	}
+	want := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:    "broken",
+			Image:   "my-image",
+			Script:  "echo hello",
+			Command: []string{"sh"},
+		}},
+	}
+	ctx := context.Background()
+	got := v1.MinimizeFailingSpec(ctx, spec)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("MinimizeFailingSpec() diff %s", diff.PrintWantGot(d))
+	}
+	// minimizing twice yields the same spec
+	if d := cmp.Diff(want, v1.MinimizeFailingSpec(ctx, got)); d != "" {
+		t.Errorf("MinimizeFailingSpec() is not stable %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMinimizeFailingSpecValidSpecUnchanged(t *testing.T) {
+	spec := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:  "fine",
+			Image: "my-image",
+		}},
+	}
+	got := v1.MinimizeFailingSpec(context.Background(), spec)
+	if d := cmp.Diff(spec, got); d != "" {
+		t.Errorf("MinimizeFailingSpec() diff %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/tektoncd/pipeline/pkg/substitution"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ApplyReplacements returns a deep copy of the TaskSpec with the given
+// replacements applied across steps, stepTemplate, sidecars, volumes and
+// workspaces. References without a matching replacement are left untouched
+// rather than erroring, so callers can see exactly what remains unresolved.
+// This mirrors the substitution the reconciler performs at runtime, exposed
+// as a pure function for tooling such as dry-run previews.
+func (ts *TaskSpec) ApplyReplacements(stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) *TaskSpec {
+	spec := ts.DeepCopy()
+	for i := range spec.Steps {
+		if spec.Steps[i].Params != nil {
+			spec.Steps[i].Params = spec.Steps[i].Params.ReplaceVariables(stringReplacements, arrayReplacements, objectReplacements)
+		}
+		applyStepSpecReplacements(&spec.Steps[i], stringReplacements, arrayReplacements)
+	}
+	if spec.StepTemplate != nil {
+		c := spec.StepTemplate.ToK8sContainer()
+		applyContainerSpecReplacements(c, stringReplacements, arrayReplacements)
+		spec.StepTemplate.SetContainerFields(*c)
+	}
+	applyVolumeSpecReplacements(spec.Volumes, stringReplacements)
+	for i := range spec.Workspaces {
+		spec.Workspaces[i].MountPath = substitution.ApplyReplacements(spec.Workspaces[i].MountPath, stringReplacements)
+	}
+	for i := range spec.Sidecars {
+		spec.Sidecars[i].Script = substitution.ApplyReplacements(spec.Sidecars[i].Script, stringReplacements)
+		c := spec.Sidecars[i].ToK8sContainer()
+		applyContainerSpecReplacements(c, stringReplacements, arrayReplacements)
+		spec.Sidecars[i].SetContainerFields(*c)
+	}
+	return spec
+}
+
+func applyStepSpecReplacements(step *Step, stringReplacements map[string]string, arrayReplacements map[string][]string) {
+	step.Script = substitution.ApplyReplacements(step.Script, stringReplacements)
+	step.OnError = OnErrorType(substitution.ApplyReplacements(string(step.OnError), stringReplacements))
+	if step.StdoutConfig != nil {
+		step.StdoutConfig.Path = substitution.ApplyReplacements(step.StdoutConfig.Path, stringReplacements)
+	}
+	if step.StderrConfig != nil {
+		step.StderrConfig.Path = substitution.ApplyReplacements(step.StderrConfig.Path, stringReplacements)
+	}
+	c := step.ToK8sContainer()
+	applyContainerSpecReplacements(c, stringReplacements, arrayReplacements)
+	step.SetContainerFields(*c)
+}
+
+func applyContainerSpecReplacements(c *corev1.Container, stringReplacements map[string]string, arrayReplacements map[string][]string) {
+	c.Name = substitution.ApplyReplacements(c.Name, stringReplacements)
+	c.Image = substitution.ApplyReplacements(c.Image, stringReplacements)
+	c.WorkingDir = substitution.ApplyReplacements(c.WorkingDir, stringReplacements)
+
+	// Use ApplyArrayReplacements so an array parameter can expand into
+	// additional command or args elements.
+	var newCommand []string
+	for _, cmd := range c.Command {
+		newCommand = append(newCommand, substitution.ApplyArrayReplacements(cmd, stringReplacements, arrayReplacements)...)
+	}
+	c.Command = newCommand
+	var newArgs []string
+	for _, a := range c.Args {
+		newArgs = append(newArgs, substitution.ApplyArrayReplacements(a, stringReplacements, arrayReplacements)...)
+	}
+	c.Args = newArgs
+
+	for ie, e := range c.Env {
+		c.Env[ie].Value = substitution.ApplyReplacements(e.Value, stringReplacements)
+		if e.ValueFrom != nil {
+			if e.ValueFrom.SecretKeyRef != nil {
+				c.Env[ie].ValueFrom.SecretKeyRef.LocalObjectReference.Name = substitution.ApplyReplacements(e.ValueFrom.SecretKeyRef.LocalObjectReference.Name, stringReplacements)
+				c.Env[ie].ValueFrom.SecretKeyRef.Key = substitution.ApplyReplacements(e.ValueFrom.SecretKeyRef.Key, stringReplacements)
+			}
+			if e.ValueFrom.ConfigMapKeyRef != nil {
+				c.Env[ie].ValueFrom.ConfigMapKeyRef.LocalObjectReference.Name = substitution.ApplyReplacements(e.ValueFrom.ConfigMapKeyRef.LocalObjectReference.Name, stringReplacements)
+				c.Env[ie].ValueFrom.ConfigMapKeyRef.Key = substitution.ApplyReplacements(e.ValueFrom.ConfigMapKeyRef.Key, stringReplacements)
+			}
+		}
+	}
+	for ie, e := range c.EnvFrom {
+		c.EnvFrom[ie].Prefix = substitution.ApplyReplacements(e.Prefix, stringReplacements)
+		if e.ConfigMapRef != nil {
+			c.EnvFrom[ie].ConfigMapRef.LocalObjectReference.Name = substitution.ApplyReplacements(e.ConfigMapRef.LocalObjectReference.Name, stringReplacements)
+		}
+		if e.SecretRef != nil {
+			c.EnvFrom[ie].SecretRef.LocalObjectReference.Name = substitution.ApplyReplacements(e.SecretRef.LocalObjectReference.Name, stringReplacements)
+		}
+	}
+	for iv, v := range c.VolumeMounts {
+		c.VolumeMounts[iv].Name = substitution.ApplyReplacements(v.Name, stringReplacements)
+		c.VolumeMounts[iv].MountPath = substitution.ApplyReplacements(v.MountPath, stringReplacements)
+		c.VolumeMounts[iv].SubPath = substitution.ApplyReplacements(v.SubPath, stringReplacements)
+	}
+}
+
+func applyVolumeSpecReplacements(volumes []corev1.Volume, stringReplacements map[string]string) {
+	for i, v := range volumes {
+		volumes[i].Name = substitution.ApplyReplacements(v.Name, stringReplacements)
+		if v.ConfigMap != nil {
+			volumes[i].ConfigMap.Name = substitution.ApplyReplacements(v.ConfigMap.Name, stringReplacements)
+			for index, item := range v.ConfigMap.Items {
+				volumes[i].ConfigMap.Items[index].Key = substitution.ApplyReplacements(item.Key, stringReplacements)
+				volumes[i].ConfigMap.Items[index].Path = substitution.ApplyReplacements(item.Path, stringReplacements)
+			}
+		}
+		if v.Secret != nil {
+			volumes[i].Secret.SecretName = substitution.ApplyReplacements(v.Secret.SecretName, stringReplacements)
+			for index, item := range v.Secret.Items {
+				volumes[i].Secret.Items[index].Key = substitution.ApplyReplacements(item.Key, stringReplacements)
+				volumes[i].Secret.Items[index].Path = substitution.ApplyReplacements(item.Path, stringReplacements)
+			}
+		}
+		if v.PersistentVolumeClaim != nil {
+			volumes[i].PersistentVolumeClaim.ClaimName = substitution.ApplyReplacements(v.PersistentVolumeClaim.ClaimName, stringReplacements)
+		}
+		if v.Projected != nil {
+			for _, s := range volumes[i].Projected.Sources {
+				if s.ConfigMap != nil {
+					s.ConfigMap.Name = substitution.ApplyReplacements(s.ConfigMap.Name, stringReplacements)
+				}
+				if s.Secret != nil {
+					s.Secret.Name = substitution.ApplyReplacements(s.Secret.Name, stringReplacements)
+				}
+				if s.ServiceAccountToken != nil {
+					s.ServiceAccountToken.Audience = substitution.ApplyReplacements(s.ServiceAccountToken.Audience, stringReplacements)
+				}
+			}
+		}
+		if v.CSI != nil {
+			if v.CSI.NodePublishSecretRef != nil {
+				volumes[i].CSI.NodePublishSecretRef.Name = substitution.ApplyReplacements(v.CSI.NodePublishSecretRef.Name, stringReplacements)
+			}
+			for key, value := range v.CSI.VolumeAttributes {
+				volumes[i].CSI.VolumeAttributes[key] = substitution.ApplyReplacements(value, stringReplacements)
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestTaskSpecApplyReplacements(t *testing.T) {
+	ts := &v1.TaskSpec{
+		StepTemplate: &v1.StepTemplate{
+			Env: []corev1.EnvVar{{
+				Name:  "REPO",
+				Value: "$(params.repo)",
+			}},
+		},
+		Steps: []v1.Step{{
+			Image:  "$(params.image)",
+			Args:   []string{"$(params.flags[*])", "--unresolved=$(params.other)"},
+			Script: "echo $(params.repo)",
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:   "watcher",
+			Image:  "sidecar-image",
+			Script: "tail -f $(params.repo)",
+		}},
+		Volumes: []corev1.Volume{{
+			Name: "secret-volume",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: "$(params.secretName)"},
+			},
+		}},
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name:      "source",
+			MountPath: "/workspace/$(params.repo)",
+		}},
+	}
+	want := &v1.TaskSpec{
+		StepTemplate: &v1.StepTemplate{
+			Env: []corev1.EnvVar{{
+				Name:  "REPO",
+				Value: "my-repo",
+			}},
+		},
+		Steps: []v1.Step{{
+			Image:  "my-image",
+			Args:   []string{"--verbose", "--dry-run", "--unresolved=$(params.other)"},
+			Script: "echo my-repo",
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:   "watcher",
+			Image:  "sidecar-image",
+			Script: "tail -f my-repo",
+		}},
+		Volumes: []corev1.Volume{{
+			Name: "secret-volume",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: "my-secret"},
+			},
+		}},
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name:      "source",
+			MountPath: "/workspace/my-repo",
+		}},
+	}
+	stringReplacements := map[string]string{
+		"params.repo":       "my-repo",
+		"params.image":      "my-image",
+		"params.secretName": "my-secret",
+	}
+	arrayReplacements := map[string][]string{
+		"params.flags": {"--verbose", "--dry-run"},
+	}
+	original := ts.DeepCopy()
+	got := ts.ApplyReplacements(stringReplacements, arrayReplacements, map[string]map[string]string{})
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyReplacements() diff %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(original, ts); d != "" {
+		t.Errorf("ApplyReplacements() modified the receiver %s", diff.PrintWantGot(d))
+	}
+}
@@ -17,11 +17,20 @@ limitations under the License.
 package v1
 
 import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/internal/checksum"
+	"github.com/tektoncd/pipeline/pkg/internal/resultref"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/kmeta"
 )
 
@@ -74,6 +83,21 @@ func (t *Task) Checksum() ([]byte, error) {
 	return sha256Checksum, nil
 }
 
+// Fingerprint returns a stable hex-encoded sha256 over the canonicalized
+// spec, for use in caching and change detection. Defaults are applied to a
+// deep copy before hashing and the JSON encoding sorts map keys, so
+// semantically identical specs produce the same fingerprint regardless of map
+// iteration order.
+func (ts *TaskSpec) Fingerprint() (string, error) {
+	spec := ts.DeepCopy()
+	spec.SetDefaults(context.Background())
+	sha256Checksum, err := checksum.ComputeSha256Checksum(spec)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sha256Checksum), nil
+}
+
 // TaskSpec defines the desired state of Task.
 type TaskSpec struct {
 	// Params is a list of input parameters required to run the task. Params
@@ -121,6 +145,225 @@ type TaskSpec struct {
 	Results []TaskResult `json:"results,omitempty"`
 }
 
+// ReferencedSecrets returns the names of all the Secrets referenced by the
+// TaskSpec's steps, sidecars, stepTemplate and volumes.
+func (ts *TaskSpec) ReferencedSecrets() sets.String {
+	secrets, _ := ts.referencedSecretsAndConfigMaps()
+	return secrets
+}
+
+// ReferencedConfigMaps returns the names of all the ConfigMaps referenced by
+// the TaskSpec's steps, sidecars, stepTemplate and volumes.
+func (ts *TaskSpec) ReferencedConfigMaps() sets.String {
+	_, configMaps := ts.referencedSecretsAndConfigMaps()
+	return configMaps
+}
+
+func (ts *TaskSpec) referencedSecretsAndConfigMaps() (sets.String, sets.String) {
+	secrets := sets.NewString()
+	configMaps := sets.NewString()
+	for _, step := range ts.Steps {
+		insertSecretAndConfigMapRefsFromContainer(step.ToK8sContainer(), secrets, configMaps)
+	}
+	for _, sidecar := range ts.Sidecars {
+		insertSecretAndConfigMapRefsFromContainer(sidecar.ToK8sContainer(), secrets, configMaps)
+	}
+	if ts.StepTemplate != nil {
+		insertSecretAndConfigMapRefsFromContainer(ts.StepTemplate.ToK8sContainer(), secrets, configMaps)
+	}
+	for _, v := range ts.Volumes {
+		if v.Secret != nil {
+			secrets.Insert(v.Secret.SecretName)
+		}
+		if v.ConfigMap != nil {
+			configMaps.Insert(v.ConfigMap.Name)
+		}
+		if v.Projected != nil {
+			for _, s := range v.Projected.Sources {
+				if s.Secret != nil {
+					secrets.Insert(s.Secret.Name)
+				}
+				if s.ConfigMap != nil {
+					configMaps.Insert(s.ConfigMap.Name)
+				}
+			}
+		}
+		if v.CSI != nil && v.CSI.NodePublishSecretRef != nil {
+			secrets.Insert(v.CSI.NodePublishSecretRef.Name)
+		}
+	}
+	return secrets, configMaps
+}
+
+// insertSecretAndConfigMapRefsFromContainer collects the Secret and ConfigMap
+// names referenced by the container's env and envFrom sources.
+func insertSecretAndConfigMapRefsFromContainer(c *corev1.Container, secrets, configMaps sets.String) {
+	for _, e := range c.Env {
+		if e.ValueFrom == nil {
+			continue
+		}
+		if e.ValueFrom.SecretKeyRef != nil {
+			secrets.Insert(e.ValueFrom.SecretKeyRef.LocalObjectReference.Name)
+		}
+		if e.ValueFrom.ConfigMapKeyRef != nil {
+			configMaps.Insert(e.ValueFrom.ConfigMapKeyRef.LocalObjectReference.Name)
+		}
+	}
+	for _, e := range c.EnvFrom {
+		if e.SecretRef != nil {
+			secrets.Insert(e.SecretRef.LocalObjectReference.Name)
+		}
+		if e.ConfigMapRef != nil {
+			configMaps.Insert(e.ConfigMapRef.LocalObjectReference.Name)
+		}
+	}
+}
+
+// ParamSignature returns a canonical string describing the TaskSpec's param
+// interface: the sorted param names with their types and whether each is
+// required (declares no default). Two specs whose params differ only in order,
+// descriptions or default values produce identical signatures, making the
+// result suitable for interface-compatibility comparison and caching.
+func (ts *TaskSpec) ParamSignature() string {
+	entries := make([]string, 0, len(ts.Params))
+	for _, p := range ts.Params {
+		required := "optional"
+		if p.Default == nil {
+			required = "required"
+		}
+		entries = append(entries, fmt.Sprintf("%s:%s:%s", p.Name, p.Type, required))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+// StepDependencyOrder returns the indices of the TaskSpec's Steps ordered so
+// that every step appears after the steps whose results it references. Steps
+// with no dependency between them keep their declared relative order. An error
+// is returned if the step-result references form a cycle.
+func (ts *TaskSpec) StepDependencyOrder() ([]int, error) {
+	nameToIdx := map[string]int{}
+	for i, s := range ts.Steps {
+		if s.Name != "" {
+			nameToIdx[s.Name] = i
+		}
+	}
+	deps := make([]sets.String, len(ts.Steps))
+	for i, s := range ts.Steps {
+		deps[i] = stepResultDependencies(s)
+	}
+
+	order := make([]int, 0, len(ts.Steps))
+	done := make([]bool, len(ts.Steps))
+	for len(order) < len(ts.Steps) {
+		progressed := false
+		for i := range ts.Steps {
+			if done[i] {
+				continue
+			}
+			ready := true
+			for name := range deps[i] {
+				if j, ok := nameToIdx[name]; ok && !done[j] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				done[i] = true
+				order = append(order, i)
+				progressed = true
+			}
+		}
+		if !progressed {
+			return nil, errors.New("cycle detected in step result dependencies")
+		}
+	}
+	return order, nil
+}
+
+// stepResultDependencies returns the names of the steps whose results the Step references.
+func stepResultDependencies(s Step) sets.String {
+	values := []string{s.Script}
+	values = append(values, s.Command...)
+	values = append(values, s.Args...)
+	for _, e := range s.Env {
+		values = append(values, e.Value)
+	}
+	names := sets.NewString()
+	for _, value := range values {
+		for _, expression := range resultref.StepResultRegex.FindAllString(value, -1) {
+			expression = strings.TrimSuffix(strings.TrimPrefix(expression, "$("), ")")
+			if pr, err := resultref.ParseStepExpression(expression); err == nil {
+				names.Insert(pr.ResourceName)
+			}
+		}
+	}
+	return names
+}
+
+// TotalResourceRequests returns the sum of the compute resource requests
+// declared by the TaskSpec's steps and sidecars, with the stepTemplate merged
+// into the steps first. Containers without requests contribute nothing. The
+// total is a rough footprint for capacity planning; it does not account for
+// the init containers or overhead the controller adds when building the pod.
+func (ts *TaskSpec) TotalResourceRequests() corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, s := range ts.MergedSteps() {
+		addResourceRequests(total, s.ComputeResources.Requests)
+	}
+	for _, sc := range ts.Sidecars {
+		addResourceRequests(total, sc.ComputeResources.Requests)
+	}
+	return total
+}
+
+// MergedSteps returns the TaskSpec's steps with the stepTemplate applied to
+// each of them, following the same precedence the controller uses: the
+// template provides defaults and step-level values win, with env vars deduped
+// by name. The result is a new slice; the spec is not mutated. If the merge
+// fails the steps are returned unmerged.
+func (ts *TaskSpec) MergedSteps() []Step {
+	steps := make([]Step, len(ts.Steps))
+	for i := range ts.Steps {
+		ts.Steps[i].DeepCopyInto(&steps[i])
+	}
+	if merged, err := MergeStepsWithStepTemplate(ts.StepTemplate, steps); err == nil {
+		return merged
+	}
+	return steps
+}
+
+// ParamGroups returns the TaskSpec's params bucketed by their declared group,
+// with ungrouped params under the empty key. Within each group, params with an
+// order come first sorted by it; params without an order follow in declaration
+// order. UIs generated from the spec can use this to lay out param forms.
+func (ts *TaskSpec) ParamGroups() map[string][]ParamSpec {
+	groups := map[string][]ParamSpec{}
+	for _, p := range ts.Params {
+		groups[p.Group] = append(groups[p.Group], p)
+	}
+	for _, params := range groups {
+		sort.SliceStable(params, func(i, j int) bool {
+			if params[i].Order == nil || params[j].Order == nil {
+				return params[j].Order == nil && params[i].Order != nil
+			}
+			return *params[i].Order < *params[j].Order
+		})
+	}
+	return groups
+}
+
+func addResourceRequests(total, requests corev1.ResourceList) {
+	for name, quantity := range requests {
+		if sum, ok := total[name]; ok {
+			sum.Add(quantity)
+			total[name] = sum
+		} else {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
+
 // TaskList contains a list of Task
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type TaskList struct {
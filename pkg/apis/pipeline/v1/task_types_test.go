@@ -23,7 +23,10 @@ import (
 	"github.com/google/go-cmp/cmp"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func TestTask_Checksum(t *testing.T) {
@@ -88,3 +91,401 @@ func TestTask_Checksum(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskSpecParamSignature(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{
+			Name: "message",
+			Type: v1.ParamTypeString,
+		}, {
+			Name:    "flags",
+			Type:    v1.ParamTypeArray,
+			Default: v1.NewStructuredValues("a", "b"),
+		}},
+	}
+	reordered := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{
+			Name:        "flags",
+			Type:        v1.ParamTypeArray,
+			Description: "different description",
+			Default:     v1.NewStructuredValues("c"),
+		}, {
+			Name: "message",
+			Type: v1.ParamTypeString,
+		}},
+	}
+	if got, want := ts.ParamSignature(), reordered.ParamSignature(); got != want {
+		t.Errorf("ParamSignature() = %q, want %q for a reordered-equal interface", got, want)
+	}
+
+	different := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{
+			Name: "message",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "flags",
+			Type: v1.ParamTypeArray,
+		}},
+	}
+	if ts.ParamSignature() == different.ParamSignature() {
+		t.Errorf("ParamSignature() = %q for interfaces with different required params", ts.ParamSignature())
+	}
+}
+
+func TestTaskSpecStepDependencyOrder(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:   "consume",
+			Image:  "my-image",
+			Script: "echo $(steps.produce.results.data)",
+		}, {
+			Name:    "produce",
+			Image:   "my-image",
+			Command: []string{"produce"},
+		}, {
+			Name:  "independent",
+			Image: "my-image",
+		}},
+	}
+	order, err := ts.StepDependencyOrder()
+	if err != nil {
+		t.Fatalf("StepDependencyOrder() returned unexpected error: %v", err)
+	}
+	if d := cmp.Diff([]int{1, 2, 0}, order); d != "" {
+		t.Errorf("StepDependencyOrder() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskSpecStepDependencyOrderCycle(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:  "first",
+			Image: "my-image",
+			Args:  []string{"$(steps.second.results.data)"},
+		}, {
+			Name:  "second",
+			Image: "my-image",
+			Args:  []string{"$(steps.first.results.data)"},
+		}},
+	}
+	if _, err := ts.StepDependencyOrder(); err == nil {
+		t.Error("StepDependencyOrder() did not return expected error for cyclic step result references")
+	}
+}
+
+func TestTaskSpecReferencedSecretsAndConfigMaps(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Env: []corev1.EnvVar{{
+				Name: "FROM_SECRET_KEY",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "env-secret"},
+						Key:                  "key",
+					},
+				},
+			}, {
+				Name: "FROM_CONFIGMAP_KEY",
+				ValueFrom: &corev1.EnvVarSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "env-configmap"},
+						Key:                  "key",
+					},
+				},
+			}},
+			EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "envfrom-secret"},
+				},
+			}, {
+				ConfigMapRef: &corev1.ConfigMapEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "envfrom-configmap"},
+				},
+			}},
+		}},
+		Sidecars: []v1.Sidecar{{
+			Image: "sidecar-image",
+			EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "sidecar-secret"},
+				},
+			}},
+		}},
+		Volumes: []corev1.Volume{{
+			Name: "secret-volume",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: "volume-secret"},
+			},
+		}, {
+			Name: "configmap-volume",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "volume-configmap"},
+				},
+			},
+		}, {
+			Name: "projected-volume",
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{
+						Secret: &corev1.SecretProjection{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "projected-secret"},
+						},
+					}, {
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "projected-configmap"},
+						},
+					}},
+				},
+			},
+		}},
+	}
+	wantSecrets := sets.NewString("env-secret", "envfrom-secret", "sidecar-secret", "volume-secret", "projected-secret")
+	wantConfigMaps := sets.NewString("env-configmap", "envfrom-configmap", "volume-configmap", "projected-configmap")
+	if d := cmp.Diff(wantSecrets, ts.ReferencedSecrets()); d != "" {
+		t.Errorf("ReferencedSecrets() %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(wantConfigMaps, ts.ReferencedConfigMaps()); d != "" {
+		t.Errorf("ReferencedConfigMaps() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskSpecTotalResourceRequests(t *testing.T) {
+	ts := &v1.TaskSpec{
+		StepTemplate: &v1.StepTemplate{
+			ComputeResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+			},
+		},
+		Steps: []v1.Step{{
+			// inherits the template's requests
+			Image: "my-image",
+		}, {
+			Image: "my-image",
+			ComputeResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+			},
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:  "logger",
+			Image: "sidecar-image",
+			ComputeResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("32Mi"),
+				},
+			},
+		}},
+	}
+	want := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("600m"),
+		corev1.ResourceMemory: resource.MustParse("160Mi"),
+	}
+	got := ts.TotalResourceRequests()
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("TotalResourceRequests() diff %s", diff.PrintWantGot(d))
+	}
+	// The merge with the stepTemplate must not leak into the spec itself.
+	if len(ts.Steps[0].ComputeResources.Requests) != 0 {
+		t.Errorf("TotalResourceRequests() mutated the spec's steps: %v", ts.Steps[0].ComputeResources.Requests)
+	}
+}
+
+func TestTaskSpecTotalResourceRequestsEmpty(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{Image: "my-image"}},
+	}
+	if got := ts.TotalResourceRequests(); len(got) != 0 {
+		t.Errorf("TotalResourceRequests() = %v, want empty", got)
+	}
+}
+
+func TestTaskSpecParamGroups(t *testing.T) {
+	two := 2
+	ten := 10
+	ts := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{
+			Name:  "registry",
+			Group: "image",
+			Order: &ten,
+		}, {
+			Name:  "tag",
+			Group: "image",
+			Order: &two,
+		}, {
+			Name:  "insecure",
+			Group: "image",
+		}, {
+			Name: "verbose",
+		}},
+	}
+
+	got := ts.ParamGroups()
+
+	want := map[string][]v1.ParamSpec{
+		"image": {{
+			Name:  "tag",
+			Group: "image",
+			Order: &two,
+		}, {
+			Name:  "registry",
+			Group: "image",
+			Order: &ten,
+		}, {
+			Name:  "insecure",
+			Group: "image",
+		}},
+		"": {{
+			Name: "verbose",
+		}},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ParamGroups() diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskSpecMergedSteps(t *testing.T) {
+	ts := &v1.TaskSpec{
+		StepTemplate: &v1.StepTemplate{
+			Image: "template-image",
+			Env: []corev1.EnvVar{{
+				Name:  "KEEP_THIS",
+				Value: "A_VALUE",
+			}, {
+				Name:  "SOME_KEY",
+				Value: "ORIGINAL_VALUE",
+			}},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "data",
+				MountPath: "/workspace/data",
+			}},
+		},
+		Steps: []v1.Step{{
+			Name:    "step-1",
+			Command: []string{"echo"},
+			Env: []corev1.EnvVar{{
+				Name:  "NEW_KEY",
+				Value: "A_VALUE",
+			}, {
+				Name:  "SOME_KEY",
+				Value: "NEW_VALUE",
+			}},
+		}, {
+			Name:  "step-2",
+			Image: "step-image",
+		}},
+	}
+	want := []v1.Step{{
+		Name:    "step-1",
+		Image:   "template-image",
+		Command: []string{"echo"},
+		Env: []corev1.EnvVar{{
+			Name:  "NEW_KEY",
+			Value: "A_VALUE",
+		}, {
+			Name:  "KEEP_THIS",
+			Value: "A_VALUE",
+		}, {
+			Name:  "SOME_KEY",
+			Value: "NEW_VALUE",
+		}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      "data",
+			MountPath: "/workspace/data",
+		}},
+	}, {
+		Name:  "step-2",
+		Image: "step-image",
+		Env: []corev1.EnvVar{{
+			Name:  "KEEP_THIS",
+			Value: "A_VALUE",
+		}, {
+			Name:  "SOME_KEY",
+			Value: "ORIGINAL_VALUE",
+		}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      "data",
+			MountPath: "/workspace/data",
+		}},
+	}}
+
+	got := ts.MergedSteps()
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("MergedSteps() %s", diff.PrintWantGot(d))
+	}
+	if ts.Steps[0].Image != "" || len(ts.Steps[0].VolumeMounts) != 0 {
+		t.Errorf("MergedSteps() mutated the spec's steps: %v", ts.Steps[0])
+	}
+}
+
+func TestTaskSpecFingerprint(t *testing.T) {
+	spec := func(properties map[string]v1.PropertySpec, objectVal map[string]string) *v1.TaskSpec {
+		return &v1.TaskSpec{
+			Params: []v1.ParamSpec{{
+				Name:       "myobj",
+				Type:       v1.ParamTypeObject,
+				Properties: properties,
+				Default: &v1.ParamValue{
+					Type:      v1.ParamTypeObject,
+					ObjectVal: objectVal,
+				},
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "my-image",
+			}},
+		}
+	}
+
+	first := spec(
+		map[string]v1.PropertySpec{"key1": {Type: "string"}, "key2": {Type: "string"}},
+		map[string]string{"key1": "foo", "key2": "bar"},
+	)
+	second := spec(
+		map[string]v1.PropertySpec{"key2": {Type: "string"}, "key1": {Type: "string"}},
+		map[string]string{"key2": "bar", "key1": "foo"},
+	)
+
+	firstFingerprint, err := first.Fingerprint()
+	if err != nil {
+		t.Fatalf("Fingerprint() = %v", err)
+	}
+	secondFingerprint, err := second.Fingerprint()
+	if err != nil {
+		t.Fatalf("Fingerprint() = %v", err)
+	}
+	if firstFingerprint != secondFingerprint {
+		t.Errorf("expected identical fingerprints, got %s and %s", firstFingerprint, secondFingerprint)
+	}
+
+	// Defaults are applied before hashing, so a spec that only spells out the
+	// inferred type fingerprints the same as one that leaves it implicit.
+	implicit := spec(
+		map[string]v1.PropertySpec{"key1": {}, "key2": {}},
+		map[string]string{"key1": "foo", "key2": "bar"},
+	)
+	implicitFingerprint, err := implicit.Fingerprint()
+	if err != nil {
+		t.Fatalf("Fingerprint() = %v", err)
+	}
+	if implicitFingerprint != firstFingerprint {
+		t.Errorf("expected identical fingerprints after defaulting, got %s and %s", implicitFingerprint, firstFingerprint)
+	}
+
+	changed := spec(
+		map[string]v1.PropertySpec{"key1": {Type: "string"}, "key2": {Type: "string"}},
+		map[string]string{"key1": "foo", "key2": "changed"},
+	)
+	changedFingerprint, err := changed.Fingerprint()
+	if err != nil {
+		t.Fatalf("Fingerprint() = %v", err)
+	}
+	if changedFingerprint == firstFingerprint {
+		t.Errorf("expected different fingerprints for different specs, both were %s", changedFingerprint)
+	}
+}
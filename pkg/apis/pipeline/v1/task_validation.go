@@ -18,10 +18,13 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -50,6 +53,19 @@ const (
 	// objectVariableNameFormat is the regext used to validate object name and key names format
 	// The difference with the array or string name format is that object variable names shouldn't contain dots.
 	objectVariableNameFormat = "^[_a-zA-Z][_a-zA-Z0-9-]*$"
+
+	// arrayIndexRefFormat matches any array indexing reference to a param, whether
+	// the index between the brackets is well formed or not.
+	arrayIndexRefFormat = `\$\(params\.[_a-zA-Z0-9.-]+\[[^\]]*\]\)`
+
+	// stepEnvVariableNameFormat is the regex used to validate env var names in
+	// steps and the stepTemplate, which must be valid shell identifiers so that
+	// scripts can read them.
+	stepEnvVariableNameFormat = "^[A-Za-z_][A-Za-z0-9_]*$"
+
+	// objectKeyIndexRefFormat matches a reference to an individual key of an object
+	// param that uses array index syntax, e.g. "$(params.myObject.key[0])".
+	objectKeyIndexRefFormat = `\$\(params\.([_a-zA-Z][_a-zA-Z0-9-]*)\.([_a-zA-Z][_a-zA-Z0-9-]*)\[[^\]]*\]\)`
 )
 
 var (
@@ -65,28 +81,73 @@ func (t *Task) SupportedVerbs() []admissionregistrationv1.OperationType {
 var (
 	stringAndArrayVariableNameFormatRegex = regexp.MustCompile(stringAndArrayVariableNameFormat)
 	objectVariableNameFormatRegex         = regexp.MustCompile(objectVariableNameFormat)
+	arrayIndexRefRegex                    = regexp.MustCompile(arrayIndexRefFormat)
+	validArrayIndexRegex                  = regexp.MustCompile(`^([0-9]+|\*)$`)
+	stepEnvVariableNameFormatRegex        = regexp.MustCompile(stepEnvVariableNameFormat)
+	objectKeyIndexRefRegex                = regexp.MustCompile(objectKeyIndexRefFormat)
+	// taskResultPathRegex and stepResultPathRegex capture the result name in
+	// task-level and step-level result path references respectively.
+	taskResultPathRegex = regexp.MustCompile(`\$\(results\.([_a-zA-Z0-9][-._a-zA-Z0-9]*)\.path\)`)
+	stepResultPathRegex = regexp.MustCompile(`\$\(step\.results\.([_a-zA-Z0-9][-._a-zA-Z0-9]*)\.path\)`)
+	// contextVariableRegex matches any reference to a $(context.*) variable,
+	// e.g. "$(context.task.name)".
+	contextVariableRegex = regexp.MustCompile(`\$\(context(\.[_a-zA-Z0-9-]+)+\)`)
+	// malformedVariableRefRegex matches variable references with leading or
+	// trailing whitespace inside the parentheses, e.g. "$( params.foo )", which
+	// silently fail to substitute. It is anchored on the known variable prefixes
+	// so that shell command substitutions in scripts are not flagged.
+	malformedVariableRefRegex = regexp.MustCompile(`\$\(\s+(?:params|results|workspaces|context|steps|step|credentials)\.[^)]*\)|\$\((?:params|results|workspaces|context|steps|step|credentials)\.[^)\s]*\s+\)`)
 )
 
 // Validate implements apis.Validatable
 func (t *Task) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(t.GetObjectMeta()).ViaField("metadata")
+	// A deprecated task is still valid; surface the deprecation notice as a
+	// non-blocking warning so that consumers get a standard signal.
+	if msg, ok := t.ObjectMeta.Annotations[pipeline.DeprecatedAnnotationKey]; ok {
+		errs = errs.Also(apis.ErrGeneric(msg, "metadata").At(apis.WarningLevel))
+	}
 	errs = errs.Also(t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 	// When a Task is created directly, instead of declared inline in a TaskRun or PipelineRun,
 	// we do not support propagated parameters. Validate that all params it uses are declared.
-	errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+	errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Sidecars, t.Spec.Params).ViaField("spec"))
 	return errs
 }
 
+// ValidatePartitioned validates the Task like Validate, but returns the
+// metadata and spec findings separately so callers can report them with
+// different granularity.
+func (t *Task) ValidatePartitioned(ctx context.Context) (meta, spec *apis.FieldError) {
+	meta = validate.ObjectMetadata(t.GetObjectMeta()).ViaField("metadata")
+	if msg, ok := t.ObjectMeta.Annotations[pipeline.DeprecatedAnnotationKey]; ok {
+		meta = meta.Also(apis.ErrGeneric(msg, "metadata").At(apis.WarningLevel))
+	}
+	spec = t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
+	spec = spec.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Sidecars, t.Spec.Params).ViaField("spec"))
+	return meta, spec
+}
+
 // Validate implements apis.Validatable
-func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+func (ts *TaskSpec) Validate(ctx context.Context) *apis.FieldError {
+	return ts.ValidateAll(ctx)
+}
+
+// ValidateAll validates the TaskSpec without short-circuiting: every
+// independent check runs and its findings are merged into the returned
+// FieldError, so bulk-lint callers see all of the problems at once rather
+// than only the first.
+func (ts *TaskSpec) ValidateAll(ctx context.Context) (errs *apis.FieldError) {
 	if len(ts.Steps) == 0 {
 		errs = errs.Also(apis.ErrMissingField("steps"))
 	}
 
 	errs = errs.Also(ValidateVolumes(ts.Volumes).ViaField("volumes"))
-	errs = errs.Also(validateDeclaredWorkspaces(ts.Workspaces, ts.Steps, ts.StepTemplate).ViaField("workspaces"))
+	errs = errs.Also(validateWorkspaceVolumeTypes(ctx, ts.Volumes).ViaField("volumes"))
+	errs = errs.Also(validateDeclaredWorkspaces(ctx, ts.Workspaces, ts.Steps, ts.StepTemplate).ViaField("workspaces"))
 	errs = errs.Also(validateWorkspaceUsages(ctx, ts))
-	mergedSteps, err := MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
+	// Merge into a copy: MergeStepsWithStepTemplate writes the merged steps
+	// back into the slice it is given, and validation must not modify ts.
+	mergedSteps, err := MergeStepsWithStepTemplate(ts.StepTemplate, append([]Step{}, ts.Steps...))
 	if err != nil {
 		errs = errs.Also(&apis.FieldError{
 			Message: fmt.Sprintf("error merging step template and steps: %s", err),
@@ -95,23 +156,285 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		})
 	}
 
+	errs = errs.Also(validateStepTemplateEnv(ts.StepTemplate).ViaField("stepTemplate"))
+	errs = errs.Also(validateStepsEnv(ts.Steps).ViaField("steps"))
+	errs = errs.Also(validateMalformedVariableRefs(ts.Steps).ViaField("steps"))
 	errs = errs.Also(validateSteps(ctx, mergedSteps).ViaField("steps"))
-	errs = errs.Also(validateSidecars(ts.Sidecars).ViaField("sidecars"))
+	errs = errs.Also(validateSecurityContextConflicts(ctx, ts.StepTemplate, ts.Steps))
+	errs = errs.Also(validateSidecars(ts.Sidecars, ts.Steps).ViaField("sidecars"))
+	errs = errs.Also(validateVolumeMountRefs(ctx, ts))
+	errs = errs.Also(validateMaxStepsPerTask(ctx, ts.Steps, ts.Sidecars))
+	errs = errs.Also(validatePinnedImages(ctx, ts.Steps, ts.Sidecars))
+	errs = errs.Also(validateImageTags(ctx, ts.Steps))
+	errs = errs.Also(validateDescriptionLengths(ctx, ts))
 	errs = errs.Also(ValidateParameterTypes(ctx, ts.Params).ViaField("params"))
+	errs = errs.Also(validateReservedParamNames(ctx, ts.Params))
 	errs = errs.Also(ValidateParameterVariables(ctx, ts.Steps, ts.Params))
 	errs = errs.Also(validateTaskContextVariables(ctx, ts.Steps))
 	errs = errs.Also(validateTaskResultsVariables(ctx, ts.Steps, ts.Results))
+	errs = errs.Also(validateSidecarResultsVariables(ctx, ts.Sidecars, ts.Results))
+	errs = errs.Also(validateStepTaskResultCollisions(ctx, ts.Steps, ts.Results))
+	errs = errs.Also(warnShadowedResultRefs(ts.Steps, ts.Results))
+	errs = errs.Also(warnMixedEnvValueSources(ts.Steps, ts.StepTemplate))
 	errs = errs.Also(validateResults(ctx, ts.Results).ViaField("results"))
+	errs = errs.Also(validateReservedResultNames(ctx, ts.Results).ViaField("results"))
+	errs = errs.Also(validateParamResultCollisions(ctx, ts.Params, ts.Results))
+	return errs
+}
+
+// ValidateWithContextVars validates the TaskSpec while restricting the context
+// variables its Steps may reference to the given allowed set. Entries are fully
+// qualified names such as "context.taskRun.namespace". References to context
+// variables outside the allowed set are reported as non-existent variables.
+func (ts *TaskSpec) ValidateWithContextVars(ctx context.Context, allowed sets.String) (errs *apis.FieldError) {
+	errs = ts.Validate(ctx)
+	taskRunContextNames := sets.NewString()
+	taskContextNames := sets.NewString()
+	for _, v := range allowed.List() {
+		switch {
+		case strings.HasPrefix(v, "context.taskRun."):
+			taskRunContextNames.Insert(strings.TrimPrefix(v, "context.taskRun."))
+		case strings.HasPrefix(v, "context.task."):
+			taskContextNames.Insert(strings.TrimPrefix(v, "context.task."))
+		}
+	}
+	errs = errs.Also(validateVariables(ctx, ts.Steps, "context\\.taskRun", taskRunContextNames))
+	return errs.Also(validateVariables(ctx, ts.Steps, "context\\.task", taskContextNames))
+}
+
+// ValidateSelfContained validates the TaskSpec and additionally requires that
+// every parameter its Steps reference is declared in Params, regardless of the
+// param propagation feature flags. Tasks published to a shared catalog cannot
+// rely on propagated parameters, so any undeclared reference is reported as a
+// non-existent variable.
+func (ts *TaskSpec) ValidateSelfContained(ctx context.Context) (errs *apis.FieldError) {
+	errs = ts.Validate(ctx)
+	return errs.Also(ValidateUsageOfDeclaredParameters(ctx, ts.Steps, ts.Sidecars, ts.Params))
+}
+
+// ValidateStableOnly validates the TaskSpec as if the cluster only enabled
+// stable API fields, regardless of the enable-api-fields value in the context.
+// Any alpha or beta gated feature the spec uses is reported with an error
+// naming the feature. Catalog publishers can use this to guarantee a task is
+// portable to stable clusters.
+func (ts *TaskSpec) ValidateStableOnly(ctx context.Context) *apis.FieldError {
+	cfg := *config.FromContextOrDefaults(ctx)
+	featureFlags := *cfg.FeatureFlags
+	featureFlags.EnableAPIFields = config.StableAPIFields
+	cfg.FeatureFlags = &featureFlags
+	return ts.Validate(config.ToContext(ctx, &cfg))
+}
+
+// ValidityUnderFlags validates the TaskSpec under each of the given feature
+// flag sets and returns the per-set results, keyed by the flag set rendered as
+// sorted "name=value" pairs joined with commas. A nil entry means the spec is
+// valid under that flag set. Operators can use this to assess the impact of a
+// planned feature flag change on existing tasks. Flag sets that cannot be
+// parsed report the parse failure as their result.
+func (ts *TaskSpec) ValidityUnderFlags(ctx context.Context, flagSets []map[string]string) map[string]*apis.FieldError {
+	results := make(map[string]*apis.FieldError, len(flagSets))
+	for _, flagSet := range flagSets {
+		key := flagSetKey(flagSet)
+		featureFlags, err := config.NewFeatureFlagsFromMap(flagSet)
+		if err != nil {
+			results[key] = apis.ErrGeneric(fmt.Sprintf("invalid feature flags: %s", err), "")
+			continue
+		}
+		cfg := *config.FromContextOrDefaults(ctx)
+		cfg.FeatureFlags = featureFlags
+		results[key] = ts.Validate(config.ToContext(ctx, &cfg))
+	}
+	return results
+}
+
+// flagSetKey renders a feature flag set as a stable string of sorted
+// "name=value" pairs joined with commas.
+func flagSetKey(flagSet map[string]string) string {
+	pairs := make([]string, 0, len(flagSet))
+	for name, value := range flagSet {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// versionedFeature names a TaskSpec feature that is only available from a
+// minimum Tekton version onwards, together with a function reporting the paths
+// at which a spec uses the feature.
+type versionedFeature struct {
+	feature    string
+	minVersion string
+	usedAt     func(ts *TaskSpec) []string
+}
+
+// versionedFeatures is the feature-availability table consulted by
+// ValidateForVersion. Features absent from the table are assumed to be
+// available at every supported version.
+var versionedFeatures = []versionedFeature{{
+	feature:    "object params",
+	minVersion: "v0.38.0",
+	usedAt: func(ts *TaskSpec) []string {
+		var paths []string
+		for i, p := range ts.Params {
+			if p.Type == ParamTypeObject {
+				paths = append(paths, fmt.Sprintf("params[%d].type", i))
+			}
+		}
+		return paths
+	},
+}, {
+	feature:    "array and object results",
+	minVersion: "v0.38.0",
+	usedAt: func(ts *TaskSpec) []string {
+		var paths []string
+		for i, r := range ts.Results {
+			if r.Type == ResultsTypeArray || r.Type == ResultsTypeObject {
+				paths = append(paths, fmt.Sprintf("results[%d].type", i))
+			}
+		}
+		return paths
+	},
+}, {
+	feature:    "step action references",
+	minVersion: "v0.54.0",
+	usedAt: func(ts *TaskSpec) []string {
+		var paths []string
+		for i, s := range ts.Steps {
+			if s.Ref != nil {
+				paths = append(paths, fmt.Sprintf("steps[%d].ref", i))
+			}
+		}
+		return paths
+	},
+}, {
+	feature:    "step results",
+	minVersion: "v0.54.0",
+	usedAt: func(ts *TaskSpec) []string {
+		var paths []string
+		for i, s := range ts.Steps {
+			if len(s.Results) > 0 {
+				paths = append(paths, fmt.Sprintf("steps[%d].results", i))
+			}
+		}
+		return paths
+	},
+}}
+
+// ValidateForVersion validates the TaskSpec and additionally checks that every
+// feature it uses is available at the given Tekton version, so specs can be
+// vetted before rolling them out across clusters running mixed versions. The
+// version is expected in the "v0.54.0" form; a missing "v" prefix or patch
+// component is tolerated.
+func (ts *TaskSpec) ValidateForVersion(ctx context.Context, version string) (errs *apis.FieldError) {
+	target, err := parseTektonVersion(version)
+	if err != nil {
+		return apis.ErrGeneric(fmt.Sprintf("invalid version %q", version), "")
+	}
+	errs = ts.Validate(ctx)
+	for _, vf := range versionedFeatures {
+		paths := vf.usedAt(ts)
+		if len(paths) == 0 {
+			continue
+		}
+		minimum, err := parseTektonVersion(vf.minVersion)
+		if err != nil {
+			continue
+		}
+		if versionLess(target, minimum) {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("%s requires Tekton version %s or newer", vf.feature, vf.minVersion),
+				Paths:   paths,
+			})
+		}
+	}
+	return errs
+}
+
+// parseTektonVersion parses a version string such as "v0.54.0" into its
+// numeric components, tolerating a missing "v" prefix.
+func parseTektonVersion(version string) ([]int, error) {
+	trimmed := strings.TrimPrefix(version, "v")
+	if trimmed == "" {
+		return nil, fmt.Errorf("empty version")
+	}
+	parts := strings.Split(trimmed, ".")
+	components := make([]int, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version component %q: %w", part, err)
+		}
+		components = append(components, n)
+	}
+	return components, nil
+}
+
+// versionLess reports whether version a precedes version b, comparing
+// component-wise and treating missing components as zero.
+func versionLess(a, b []int) bool {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		av, bv := 0, 0
+		if i < len(a) {
+			av = a[i]
+		}
+		if i < len(b) {
+			bv = b[i]
+		}
+		if av != bv {
+			return av < bv
+		}
+	}
+	return false
+}
+
+// ValidateWarnings returns warning-level findings for the TaskSpec that should
+// not fail validation. It reports params that are declared but never referenced
+// by the spec's steps, sidecars, stepTemplate, volumes or workspaces. A
+// reference to an object param's key or to an index of an array param counts as
+// a use of the param. Only declared params can be reported, so params relying
+// on propagation are never flagged. Params that are used but declare a
+// DeprecationMessage are also reported, so consumers get a nudge to migrate.
+// Step scripts that do not start with a shebang line are reported as well.
+func (ts *TaskSpec) ValidateWarnings(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(warnScriptsMissingShebang(ts.Steps))
+	if len(ts.Params) == 0 {
+		return errs
+	}
+	paramsRefs := []string{}
+	paramsRefs = append(paramsRefs, extractParamRefsFromSteps(ts.Steps)...)
+	paramsRefs = append(paramsRefs, extractParamRefsFromStepTemplate(ts.StepTemplate)...)
+	paramsRefs = append(paramsRefs, extractParamRefsFromVolumes(ts.Volumes)...)
+	for _, w := range ts.Workspaces {
+		paramsRefs = append(paramsRefs, w.MountPath)
+	}
+	paramsRefs = append(paramsRefs, extractParamRefsFromSidecars(ts.Sidecars)...)
+
+	used := sets.NewString()
+	for _, ref := range paramsRefs {
+		names, _, _ := substitution.ExtractVariablesFromString(ref, "params")
+		for _, name := range names {
+			// an indexing reference like "myArray[0]" is a use of the array param itself
+			used.Insert(strings.SplitN(name, "[", 2)[0])
+		}
+	}
+	for _, p := range ts.Params {
+		if !used.Has(p.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param %q is declared but not used", p.Name), "").ViaFieldKey("params", p.Name).At(apis.WarningLevel))
+		} else if p.DeprecationMessage != "" {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param %q is deprecated: %s", p.Name, p.DeprecationMessage), "").ViaFieldKey("params", p.Name).At(apis.WarningLevel))
+		}
+	}
 	return errs
 }
 
 // ValidateUsageOfDeclaredParameters validates that all parameters referenced in the Task are declared by the Task.
-func ValidateUsageOfDeclaredParameters(ctx context.Context, steps []Step, params ParamSpecs) *apis.FieldError {
+func ValidateUsageOfDeclaredParameters(ctx context.Context, steps []Step, sidecars []Sidecar, params ParamSpecs) *apis.FieldError {
 	var errs *apis.FieldError
 	_, _, objectParams := params.SortByType()
 	allParameterNames := sets.NewString(params.GetNames()...)
 	errs = errs.Also(validateVariables(ctx, steps, "params", allParameterNames))
-	errs = errs.Also(validateObjectUsage(ctx, steps, objectParams))
+	errs = errs.Also(validateSidecarsVariables(sidecars, "params", allParameterNames))
+	errs = errs.Also(validateObjectUsage(ctx, steps, sidecars, objectParams))
 	errs = errs.Also(ValidateObjectParamsHaveProperties(ctx, params))
 	return errs
 }
@@ -127,10 +450,29 @@ func ValidateObjectParamsHaveProperties(ctx context.Context, params ParamSpecs)
 	return errs
 }
 
-func validateSidecars(sidecars []Sidecar) (errs *apis.FieldError) {
-	for _, sc := range sidecars {
+func validateSidecars(sidecars []Sidecar, steps []Step) (errs *apis.FieldError) {
+	// Sidecar names must be unique and must not collide with step names, since
+	// steps and sidecars all become containers in the same pod.
+	stepNames := sets.NewString()
+	for _, s := range steps {
+		if s.Name != "" {
+			stepNames.Insert(s.Name)
+		}
+	}
+	sidecarNames := sets.NewString()
+	for idx, sc := range sidecars {
 		errs = errs.Also(validateSidecarName(sc))
 
+		if sc.Name != "" {
+			if sidecarNames.Has(sc.Name) {
+				errs = errs.Also(apis.ErrInvalidValue(sc.Name, "name").ViaIndex(idx))
+			}
+			if stepNames.Has(sc.Name) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("sidecar name %q conflicts with step name", sc.Name), "name").ViaIndex(idx))
+			}
+			sidecarNames.Insert(sc.Name)
+		}
+
 		if sc.Image == "" {
 			errs = errs.Also(apis.ErrMissingField("image"))
 		}
@@ -158,15 +500,200 @@ func validateSidecarName(sc Sidecar) (errs *apis.FieldError) {
 }
 
 func validateResults(ctx context.Context, results []TaskResult) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for index, result := range results {
+		// Report duplicates before the per-result name-format validation so the
+		// more relevant error surfaces first. Result names are case-sensitive.
+		if seen.Has(result.Name) {
+			errs = errs.Also(apis.ErrGeneric("result appears more than once", "name").ViaIndex(index))
+		}
+		seen.Insert(result.Name)
+	}
 	for index, result := range results {
 		errs = errs.Also(result.Validate(ctx).ViaIndex(index))
 	}
 	return errs
 }
 
+// reservedParamNames are param names that collide with the prefixes of other
+// variable families, e.g. a param named "context" makes $(context.task.name)
+// ambiguous. "credentials" is reserved for the legacy creds-init volume names.
+var reservedParamNames = sets.NewString("context", "credentials")
+
+// validateReservedParamNames rejects params whose name matches a reserved
+// variable prefix. It is gated behind the forbid-reserved-param-names feature
+// flag so existing tasks with such names keep working until they migrate.
+func validateReservedParamNames(ctx context.Context, params ParamSpecs) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.ForbidReservedParamNames {
+		return nil
+	}
+	for _, p := range params {
+		if reservedParamNames.Has(p.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param name %q is reserved", p.Name), "params"))
+		}
+	}
+	return errs
+}
+
+// reservedResultNames are result names that collide with the roots of context
+// variables, e.g. a result named "task" makes $(results.task.path) and
+// $(context.task.name) easy to confuse.
+var reservedResultNames = sets.NewString("task", "taskRun", "steps", "step", "params")
+
+// validateReservedResultNames rejects task results whose name matches a
+// reserved context variable root. It is gated behind the
+// forbid-reserved-result-names feature flag so existing tasks with such names
+// keep working until they migrate.
+func validateReservedResultNames(ctx context.Context, results []TaskResult) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.ForbidReservedResultNames {
+		return nil
+	}
+	for index, result := range results {
+		if reservedResultNames.Has(result.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result name %q is reserved", result.Name), "name").ViaIndex(index))
+		}
+	}
+	return errs
+}
+
+// validateParamResultCollisions rejects tasks where a param and a result
+// share a name. The two live in different variable namespaces so a collision
+// is legal, but it is a reliable source of confusion. Gated on the
+// "forbid-param-result-collisions" feature flag so existing tasks keep
+// working by default.
+func validateParamResultCollisions(ctx context.Context, params ParamSpecs, results []TaskResult) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.ForbidParamResultCollisions {
+		return nil
+	}
+	paramNames := sets.NewString(params.GetNames()...)
+	for index, result := range results {
+		if paramNames.Has(result.Name) {
+			errs = errs.Also((&apis.FieldError{
+				Message: fmt.Sprintf("name %q used for both a param and a result", result.Name),
+				Paths:   []string{"name"},
+			}).ViaFieldIndex("results", index))
+		}
+	}
+	return errs
+}
+
+// validateMaxStepsPerTask checks the number of steps and sidecars against the
+// MaxStepsPerTask configured in the defaults config. Steps and sidecars count
+// separately under the same limit and zero means no limit is enforced.
+func validateMaxStepsPerTask(ctx context.Context, steps []Step, sidecars []Sidecar) (errs *apis.FieldError) {
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+	if defaults == nil || defaults.MaxStepsPerTask <= 0 {
+		return nil
+	}
+	if len(steps) > defaults.MaxStepsPerTask {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("too many steps, max is %d", defaults.MaxStepsPerTask),
+			Paths:   []string{"steps"},
+		})
+	}
+	if len(sidecars) > defaults.MaxStepsPerTask {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("too many sidecars, max is %d", defaults.MaxStepsPerTask),
+			Paths:   []string{"sidecars"},
+		})
+	}
+	return errs
+}
+
+// validateDescriptionLengths checks param, result and workspace descriptions
+// against the MaxDescriptionLength configured in the defaults config. Zero
+// means no limit is enforced.
+func validateDescriptionLengths(ctx context.Context, ts *TaskSpec) (errs *apis.FieldError) {
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+	if defaults == nil || defaults.MaxDescriptionLength <= 0 {
+		return nil
+	}
+	for idx, p := range ts.Params {
+		errs = errs.Also(validateDescriptionLength(p.Description, defaults.MaxDescriptionLength).ViaFieldIndex("params", idx))
+	}
+	for idx, r := range ts.Results {
+		errs = errs.Also(validateDescriptionLength(r.Description, defaults.MaxDescriptionLength).ViaFieldIndex("results", idx))
+	}
+	for idx, w := range ts.Workspaces {
+		errs = errs.Also(validateDescriptionLength(w.Description, defaults.MaxDescriptionLength).ViaFieldIndex("workspaces", idx))
+	}
+	return errs
+}
+
+// validateDescriptionLength returns an error if the description is longer
+// than the given maximum.
+func validateDescriptionLength(description string, maxLength int) *apis.FieldError {
+	if len(description) > maxLength {
+		return apis.ErrGeneric(fmt.Sprintf("description is %d characters long, max is %d", len(description), maxLength), "description")
+	}
+	return nil
+}
+
+// validatePinnedImages checks that step and sidecar images are pinned by
+// digest when the require-pinned-images feature flag is enabled. Images
+// containing param references are skipped since they cannot be resolved at
+// admission time.
+func validatePinnedImages(ctx context.Context, steps []Step, sidecars []Sidecar) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.RequirePinnedImages {
+		return nil
+	}
+	for idx, s := range steps {
+		errs = errs.Also(validatePinnedImage(s.Image).ViaFieldIndex("steps", idx))
+	}
+	for idx, sc := range sidecars {
+		errs = errs.Also(validatePinnedImage(sc.Image).ViaFieldIndex("sidecars", idx))
+	}
+	return errs
+}
+
+// validatePinnedImage returns an error if the image is not pinned by digest.
+func validatePinnedImage(image string) *apis.FieldError {
+	if image == "" || strings.Contains(image, "$(") {
+		return nil
+	}
+	if !strings.Contains(image, "@") {
+		return apis.ErrGeneric(fmt.Sprintf("image %q must be pinned by digest when %s is enabled", image, config.RequirePinnedImages), "image")
+	}
+	return nil
+}
+
+// validateImageTags checks that step images carry an explicit non-latest tag
+// or a digest when the require-image-digest-or-tag feature flag is enabled.
+// Images containing param references are skipped since they cannot be
+// resolved at admission time.
+func validateImageTags(ctx context.Context, steps []Step) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.RequireImageDigestOrTag {
+		return nil
+	}
+	for idx, s := range steps {
+		errs = errs.Also(validateImageTag(s.Image).ViaFieldIndex("steps", idx))
+	}
+	return errs
+}
+
+// validateImageTag returns an error if the image is untagged or tagged "latest".
+func validateImageTag(image string) *apis.FieldError {
+	if image == "" || strings.Contains(image, "$(") {
+		return nil
+	}
+	if strings.Contains(image, "@") {
+		return nil
+	}
+	// Only a colon after the last slash separates a tag from the repository;
+	// earlier colons belong to a registry host:port.
+	tag := ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		tag = image[i+1:]
+	}
+	if tag == "" || tag == "latest" {
+		return apis.ErrGeneric("image must specify a non-latest tag or digest", "image")
+	}
+	return nil
+}
+
 // a mount path which conflicts with any other declared workspaces, with the explicitly
 // declared volume mounts, or with the stepTemplate. The names must also be unique.
-func validateDeclaredWorkspaces(workspaces []WorkspaceDeclaration, steps []Step, stepTemplate *StepTemplate) (errs *apis.FieldError) {
+func validateDeclaredWorkspaces(ctx context.Context, workspaces []WorkspaceDeclaration, steps []Step, stepTemplate *StepTemplate) (errs *apis.FieldError) {
 	mountPaths := sets.NewString()
 	for _, step := range steps {
 		for _, vm := range step.VolumeMounts {
@@ -187,8 +714,18 @@ func validateDeclaredWorkspaces(workspaces []WorkspaceDeclaration, steps []Step,
 		} else {
 			wsNames.Insert(w.Name)
 		}
+		// Results aren't available when workspaces are mounted, so result references
+		// in a mount path can never be resolved.
+		if strings.Contains(w.MountPath, "$(results.") {
+			errs = errs.Also(apis.ErrGeneric("result references not allowed in mount path", "mountpath").ViaIndex(idx))
+		}
+		// An empty MountPath resolves to the default /workspace/<name>; an explicit
+		// MountPath must be absolute to avoid confusing pod behavior.
+		if w.MountPath != "" && !filepath.IsAbs(w.MountPath) {
+			errs = errs.Also(apis.ErrGeneric("mount path must be absolute", "mountpath").ViaIndex(idx))
+		}
 		// Workspaces must not try to use mount paths that are already used
-		mountPath := filepath.Clean(w.GetMountPath())
+		mountPath := filepath.Clean(w.GetMountPathWithContext(ctx))
 		if _, ok := mountPaths[mountPath]; ok {
 			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("workspace mount path %q must be unique", mountPath), "mountpath").ViaIndex(idx))
 		}
@@ -197,6 +734,122 @@ func validateDeclaredWorkspaces(workspaces []WorkspaceDeclaration, steps []Step,
 	return errs
 }
 
+// validateStepTemplateEnv validates that each env var name declared in the
+// stepTemplate is a valid shell identifier, applying the same rules as the
+// env vars declared directly in steps. Names that are variable references are
+// skipped since they can't be checked until they are resolved.
+func validateStepTemplateEnv(stepTemplate *StepTemplate) (errs *apis.FieldError) {
+	if stepTemplate == nil {
+		return nil
+	}
+	for _, env := range stepTemplate.Env {
+		if strings.Contains(env.Name, "$(") {
+			continue
+		}
+		if !stepEnvVariableNameFormatRegex.MatchString(env.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("invalid environment variable name %q", env.Name), "").ViaFieldKey("env", env.Name))
+		}
+	}
+	return errs
+}
+
+// validateStepsEnv validates that each env var name declared directly in a step
+// is a valid shell identifier, so that scripts can read the variable. Names
+// that are variable references are skipped since they can't be checked until
+// they are resolved. Env vars inherited from the stepTemplate are covered by
+// validateStepTemplateEnv instead.
+func validateStepsEnv(steps []Step) (errs *apis.FieldError) {
+	for idx, s := range steps {
+		for j, env := range s.Env {
+			if strings.Contains(env.Name, "$(") {
+				continue
+			}
+			if !stepEnvVariableNameFormatRegex.MatchString(env.Name) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("invalid environment variable name %q", env.Name), "name").ViaFieldIndex("env", j).ViaIndex(idx))
+			}
+		}
+	}
+	return errs
+}
+
+// validateSecurityContextConflicts rejects steps whose effective
+// securityContext asks for runAsNonRoot while also running as user 0, which
+// would make the pod fail to start. The effective value of each field is the
+// step's own setting if present, falling back to the stepTemplate, matching
+// the precedence used when the containers are merged. Gated on the
+// "validate-security-context-conflicts" feature flag.
+func validateSecurityContextConflicts(ctx context.Context, stepTemplate *StepTemplate, steps []Step) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.ValidateSecurityContextConflicts {
+		return nil
+	}
+	var templateNonRoot *bool
+	var templateUser *int64
+	if stepTemplate != nil && stepTemplate.SecurityContext != nil {
+		templateNonRoot = stepTemplate.SecurityContext.RunAsNonRoot
+		templateUser = stepTemplate.SecurityContext.RunAsUser
+	}
+	for idx, s := range steps {
+		runAsNonRoot := templateNonRoot
+		runAsUser := templateUser
+		if s.SecurityContext != nil {
+			if s.SecurityContext.RunAsNonRoot != nil {
+				runAsNonRoot = s.SecurityContext.RunAsNonRoot
+			}
+			if s.SecurityContext.RunAsUser != nil {
+				runAsUser = s.SecurityContext.RunAsUser
+			}
+		}
+		if runAsNonRoot != nil && *runAsNonRoot && runAsUser != nil && *runAsUser == 0 {
+			errs = errs.Also((&apis.FieldError{
+				Message: "runAsNonRoot conflicts with runAsUser 0",
+				Paths:   []string{"securityContext"},
+			}).ViaFieldIndex("steps", idx))
+		}
+	}
+	return errs
+}
+
+// validateMalformedVariableRefs checks step fields for variable references with
+// whitespace inside the parentheses, e.g. "$( params.foo )". These silently
+// fail to substitute and otherwise surface only as confusing non-existent
+// variable errors.
+func validateMalformedVariableRefs(steps []Step) (errs *apis.FieldError) {
+	for idx, s := range steps {
+		errs = errs.Also(validateStepMalformedVariableRefs(s).ViaIndex(idx))
+	}
+	return errs
+}
+
+func validateStepMalformedVariableRefs(s Step) *apis.FieldError {
+	errs := malformedVariableRefs(s.Name).ViaField("name")
+	errs = errs.Also(malformedVariableRefs(s.Image).ViaField("image"))
+	errs = errs.Also(malformedVariableRefs(s.WorkingDir).ViaField("workingDir"))
+	errs = errs.Also(malformedVariableRefs(s.Script).ViaField("script"))
+	for i, cmd := range s.Command {
+		errs = errs.Also(malformedVariableRefs(cmd).ViaFieldIndex("command", i))
+	}
+	for i, arg := range s.Args {
+		errs = errs.Also(malformedVariableRefs(arg).ViaFieldIndex("args", i))
+	}
+	for _, env := range s.Env {
+		errs = errs.Also(malformedVariableRefs(env.Value).ViaFieldKey("env", env.Name))
+	}
+	return errs
+}
+
+// malformedVariableRefs returns an error for each whitespace-padded variable
+// reference found in the value.
+func malformedVariableRefs(value string) (errs *apis.FieldError) {
+	for _, ref := range malformedVariableRefRegex.FindAllString(value, -1) {
+		errs = errs.Also(&apis.FieldError{
+			Message: "malformed variable reference",
+			Paths:   []string{""},
+			Details: ref,
+		})
+	}
+	return errs
+}
+
 // validateWorkspaceUsages checks that all WorkspaceUsage objects in Steps
 // refer to workspaces that are defined in the Task.
 //
@@ -208,8 +861,28 @@ func validateWorkspaceUsages(ctx context.Context, ts *TaskSpec) (errs *apis.Fiel
 	sidecars := ts.Sidecars
 
 	wsNames := sets.NewString()
+	declarations := map[string]WorkspaceDeclaration{}
 	for _, w := range workspaces {
 		wsNames.Insert(w.Name)
+		declarations[w.Name] = w
+	}
+
+	// validateUsage reports problems with a single step or sidecar workspace
+	// usage. Referencing an undefined workspace is an error; referencing an
+	// optional workspace warns that the mount may be absent at runtime, and is
+	// an error when the workspace is also read-only since the container then
+	// has nothing it can do with the missing mount.
+	validateUsage := func(w WorkspaceUsage) (errs *apis.FieldError) {
+		if !wsNames.Has(w.Name) {
+			return apis.ErrGeneric(fmt.Sprintf("undefined workspace %q", w.Name), "name")
+		}
+		decl := declarations[w.Name]
+		if decl.Optional && decl.ReadOnly {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("optional workspace %q is read-only", w.Name), "name"))
+		} else if decl.Optional {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("workspace %q is optional and may be absent at runtime", w.Name), "name").At(apis.WarningLevel))
+		}
+		return errs
 	}
 
 	for stepIdx, step := range steps {
@@ -217,9 +890,7 @@ func validateWorkspaceUsages(ctx context.Context, ts *TaskSpec) (errs *apis.Fiel
 			errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step workspaces", config.BetaAPIFields).ViaIndex(stepIdx).ViaField("steps"))
 		}
 		for workspaceIdx, w := range step.Workspaces {
-			if !wsNames.Has(w.Name) {
-				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("undefined workspace %q", w.Name), "name").ViaIndex(workspaceIdx).ViaField("workspaces").ViaIndex(stepIdx).ViaField("steps"))
-			}
+			errs = errs.Also(validateUsage(w).ViaIndex(workspaceIdx).ViaField("workspaces").ViaIndex(stepIdx).ViaField("steps"))
 		}
 	}
 
@@ -228,15 +899,55 @@ func validateWorkspaceUsages(ctx context.Context, ts *TaskSpec) (errs *apis.Fiel
 			errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "sidecar workspaces", config.BetaAPIFields).ViaIndex(sidecarIdx).ViaField("sidecars"))
 		}
 		for workspaceIdx, w := range sidecar.Workspaces {
-			if !wsNames.Has(w.Name) {
-				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("undefined workspace %q", w.Name), "name").ViaIndex(workspaceIdx).ViaField("workspaces").ViaIndex(sidecarIdx).ViaField("sidecars"))
-			}
+			errs = errs.Also(validateUsage(w).ViaIndex(workspaceIdx).ViaField("workspaces").ViaIndex(sidecarIdx).ViaField("sidecars"))
 		}
 	}
 
 	return errs
 }
 
+// validateVolumeMountRefs cross-checks every step, sidecar and stepTemplate
+// volumeMount against the volumes the pod will actually have: the spec's
+// declared Volumes and the volumes backing declared workspaces. A mount whose
+// name matches neither produces a pod that won't schedule. Names containing
+// unresolved variable references are skipped since they can't be checked until
+// they are substituted. It is gated behind the require-declared-volume-mounts
+// feature flag because volumes can also be supplied at runtime via the
+// TaskRun's podTemplate, which spec validation cannot see.
+func validateVolumeMountRefs(ctx context.Context, ts *TaskSpec) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.RequireDeclaredVolumeMounts {
+		return nil
+	}
+	knownVolumes := sets.NewString()
+	for _, v := range ts.Volumes {
+		knownVolumes.Insert(v.Name)
+	}
+	for _, w := range ts.Workspaces {
+		knownVolumes.Insert(w.Name)
+	}
+	checkMounts := func(mounts []corev1.VolumeMount) (errs *apis.FieldError) {
+		for idx, vm := range mounts {
+			if strings.Contains(vm.Name, "$(") {
+				continue
+			}
+			if !knownVolumes.Has(vm.Name) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("volumeMount references unknown volume %q", vm.Name), "name").ViaFieldIndex("volumeMounts", idx))
+			}
+		}
+		return errs
+	}
+	if ts.StepTemplate != nil {
+		errs = errs.Also(checkMounts(ts.StepTemplate.VolumeMounts).ViaField("stepTemplate"))
+	}
+	for idx, s := range ts.Steps {
+		errs = errs.Also(checkMounts(s.VolumeMounts).ViaIndex(idx).ViaField("steps"))
+	}
+	for idx, sc := range ts.Sidecars {
+		errs = errs.Also(checkMounts(sc.VolumeMounts).ViaIndex(idx).ViaField("sidecars"))
+	}
+	return errs
+}
+
 // ValidateVolumes validates a slice of volumes to make sure there are no dupilcate names
 func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 	// Task must not have duplicate volume names.
@@ -251,6 +962,45 @@ func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 	return errs
 }
 
+// validateWorkspaceVolumeTypes rejects declared volumes whose type is not in the
+// AllowedWorkspaceVolumeTypes configured in the defaults config. When no allowed
+// types are configured, all volume types are accepted.
+func validateWorkspaceVolumeTypes(ctx context.Context, volumes []corev1.Volume) (errs *apis.FieldError) {
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+	if defaults == nil || len(defaults.AllowedWorkspaceVolumeTypes) == 0 {
+		return nil
+	}
+	allowedTypes := sets.NewString(defaults.AllowedWorkspaceVolumeTypes...)
+	for idx, v := range volumes {
+		if volumeType := volumeSourceType(v.VolumeSource); volumeType != "" && !allowedTypes.Has(volumeType) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("volume type %q is not allowed", volumeType), "").ViaIndex(idx))
+		}
+	}
+	return errs
+}
+
+// volumeSourceType returns the name of the volume source type backing the volume,
+// or an empty string if the type is not one task workspaces can be backed by.
+func volumeSourceType(vs corev1.VolumeSource) string {
+	switch {
+	case vs.EmptyDir != nil:
+		return "emptyDir"
+	case vs.HostPath != nil:
+		return "hostPath"
+	case vs.Secret != nil:
+		return "secret"
+	case vs.ConfigMap != nil:
+		return "configMap"
+	case vs.PersistentVolumeClaim != nil:
+		return "persistentVolumeClaim"
+	case vs.Projected != nil:
+		return "projected"
+	case vs.CSI != nil:
+		return "csi"
+	}
+	return ""
+}
+
 func validateSteps(ctx context.Context, steps []Step) (errs *apis.FieldError) {
 	// Task must not have duplicate step names.
 	names := sets.NewString()
@@ -377,6 +1127,59 @@ func validateStepResultReference(s Step) (errs *apis.FieldError) {
 	return errs
 }
 
+// warnDuplicateEnvFromPrefixes returns a warning if two of the Step's EnvFrom
+// sources share a non-empty prefix, since key collisions between the two
+// sources are resolved nondeterministically.
+func warnDuplicateEnvFromPrefixes(s Step) (errs *apis.FieldError) {
+	prefixes := sets.NewString()
+	for _, e := range s.EnvFrom {
+		if e.Prefix == "" {
+			continue
+		}
+		if prefixes.Has(e.Prefix) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("multiple envFrom sources with same prefix %q", e.Prefix), "envFrom").At(apis.WarningLevel))
+		}
+		prefixes.Insert(e.Prefix)
+	}
+	return errs
+}
+
+// warnMixedResultPathForms returns a warning if the Step's script writes both
+// $(results.<name>.path) and $(step.results.<name>.path) for the same result
+// name, since mixing the task-level and step-level forms for one logical
+// output is confusing.
+func warnMixedResultPathForms(s Step) (errs *apis.FieldError) {
+	taskForm := resultPathNames(s.Script, taskResultPathRegex)
+	stepForm := resultPathNames(s.Script, stepResultPathRegex)
+	for _, name := range taskForm.Intersection(stepForm).List() {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("script writes both $(results.%s.path) and $(step.results.%s.path)", name, name), "script").At(apis.WarningLevel))
+	}
+	return errs
+}
+
+// warnLegacyResultPaths issues a warning for each legacy $(results.x.path)
+// reference in the step's script, suggesting the $(step.results.x.path) form,
+// when the prefer-step-results feature flag is enabled.
+func warnLegacyResultPaths(ctx context.Context, s Step) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.PreferStepResults {
+		return nil
+	}
+	for _, name := range resultPathNames(s.Script, taskResultPathRegex).List() {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("legacy result path $(results.%s.path) is used; prefer $(step.results.%s.path)", name, name), "script").At(apis.WarningLevel))
+	}
+	return errs
+}
+
+// resultPathNames returns the result names captured by the regexp's first
+// submatch in the script.
+func resultPathNames(script string, re *regexp.Regexp) sets.String {
+	names := sets.NewString()
+	for _, m := range re.FindAllStringSubmatch(script, -1) {
+		names.Insert(m[1])
+	}
+	return names
+}
+
 func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.FieldError) {
 	if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableArtifacts {
 		var t []string
@@ -396,6 +1199,17 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 			return apis.ErrGeneric(fmt.Sprintf("feature flag %s should be set to true to reference StepActions in Steps.", config.EnableStepActions), "")
 		}
 		errs = errs.Also(s.Ref.Validate(ctx))
+		if config.FromContextOrDefaults(ctx).FeatureFlags.RequireStepActionChecksum {
+			hasChecksum := false
+			for _, p := range s.Ref.Params {
+				if p.Name == "checksum" {
+					hasChecksum = true
+				}
+			}
+			if !hasChecksum {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("expected checksum param is required on step refs when %s is enabled", config.RequireStepActionChecksum), "ref"))
+			}
+		}
 		if s.Image != "" {
 			errs = errs.Also(&apis.FieldError{
 				Message: "image cannot be used with Ref",
@@ -444,6 +1258,12 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 				Paths:   []string{"results"},
 			})
 		}
+		if s.Timeout != nil {
+			errs = errs.Also(&apis.FieldError{
+				Message: "timeout cannot be used with Ref",
+				Paths:   []string{"timeout"},
+			})
+		}
 	} else {
 		if len(s.Params) > 0 {
 			errs = errs.Also(&apis.FieldError{
@@ -468,8 +1288,15 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 				})
 			}
 		}
+
+		if config.FromContextOrDefaults(ctx).FeatureFlags.RequireExplicitCommand && len(s.Command) == 0 && s.Script == "" {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("expected command or script to be set when %s is enabled", config.RequireExplicitCommand), "command"))
+		}
 	}
 
+	if s.Name == "" && config.FromContextOrDefaults(ctx).FeatureFlags.RequireExplicitStepNames {
+		errs = errs.Also(apis.ErrGeneric("step name is required", "name"))
+	}
 	if s.Name != "" {
 		if names.Has(s.Name) {
 			errs = errs.Also(apis.ErrInvalidValue(s.Name, "name"))
@@ -485,8 +1312,13 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 	}
 
 	if s.Timeout != nil {
-		if s.Timeout.Duration < time.Duration(0) {
-			return apis.ErrInvalidValue(s.Timeout.Duration, "negative timeout")
+		// A zero timeout is nilled out during defaulting, so any non-positive
+		// value that survives to validation is an error.
+		if s.Timeout.Duration <= time.Duration(0) {
+			return &apis.FieldError{
+				Message: "timeout must be greater than 0",
+				Paths:   []string{"timeout"},
+			}
 		}
 	}
 
@@ -528,6 +1360,10 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step stderr stream support", config.AlphaAPIFields).ViaField("stderrconfig"))
 	}
 
+	errs = errs.Also(warnDuplicateEnvFromPrefixes(s))
+	errs = errs.Also(warnMixedResultPathForms(s))
+	errs = errs.Also(warnLegacyResultPaths(ctx, s))
+
 	// Validate usage of step result reference.
 	// Referencing previous step's results are only allowed in `env`, `command` and `args`.
 	errs = errs.Also(validateStepResultReference(s))
@@ -559,8 +1395,41 @@ func (p ParamSpec) ValidateType(ctx context.Context) *apis.FieldError {
 		return apis.ErrInvalidValue(p.Type, p.Name+".type")
 	}
 
+	// Number params carry their values as strings on the wire; the declared type
+	// only adds the requirement that defaults (and later, supplied values) parse
+	// as numbers.
+	if p.Type == ParamTypeNumber {
+		if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableParamNumber {
+			return apis.ErrGeneric(fmt.Sprintf("feature flag `%s` should be set to true to use number type param", config.EnableParamNumber), p.Name+".type")
+		}
+		if p.Default != nil {
+			if p.Default.Type != ParamTypeString {
+				return &apis.FieldError{
+					Message: fmt.Sprintf(
+						"\"%v\" type does not match default value's type: \"%v\"", p.Type, p.Default.Type),
+					Paths: []string{
+						p.Name + ".type",
+						p.Name + ".default.type",
+					},
+				}
+			}
+			if _, err := strconv.ParseFloat(p.Default.StringVal, 64); err != nil {
+				return apis.ErrInvalidValue(p.Default.StringVal, p.Name+".default")
+			}
+		}
+		return nil
+	}
+
 	// If a default value is provided, ensure its type matches param's declared type.
 	if (p.Default != nil) && (p.Default.Type != p.Type) {
+		// An object default whose JSON contains nested non-string values fails to
+		// unmarshal into ObjectVal and lands in StringVal; report the offending
+		// keys instead of a generic type mismatch.
+		if p.Type == ParamTypeObject && p.Default.Type == ParamTypeString && strings.HasPrefix(strings.TrimSpace(p.Default.StringVal), "{") {
+			if errs := p.validateObjectDefaultValues(); errs != nil {
+				return errs
+			}
+		}
 		return &apis.FieldError{
 			Message: fmt.Sprintf(
 				"\"%v\" type does not match default value's type: \"%v\"", p.Type, p.Default.Type),
@@ -575,6 +1444,27 @@ func (p ParamSpec) ValidateType(ctx context.Context) *apis.FieldError {
 	return p.ValidateObjectType(ctx)
 }
 
+// validateObjectDefaultValues checks that every value in an object param's
+// default is a string, as required by the string-typed properties.
+func (p ParamSpec) validateObjectDefaultValues() *apis.FieldError {
+	var defaultValues map[string]interface{}
+	if err := json.Unmarshal([]byte(p.Default.StringVal), &defaultValues); err != nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	keys := make([]string, 0, len(defaultValues))
+	for key := range defaultValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, ok := defaultValues[key].(string); !ok {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("object default value for key %s must be a string", key), "default").ViaKey(p.Name))
+		}
+	}
+	return errs
+}
+
 // ValidateObjectType checks that object type parameter does not miss the
 // definition of `properties` section and the type of a PropertySpec is allowed.
 // (Currently, only string is allowed)
@@ -593,7 +1483,42 @@ func (p ParamSpec) ValidateObjectType(ctx context.Context) *apis.FieldError {
 		}
 	}
 
-	return nil
+	// With the require-complete-object-defaults feature flag enabled, an object
+	// default must provide a value for every declared property key. Partial
+	// defaults stay allowed when the flag is off.
+	if config.FromContextOrDefaults(ctx).FeatureFlags.RequireCompleteObjectDefaults && p.Type == ParamTypeObject && p.Default != nil {
+		missingKeys := []string{}
+		for key := range p.Properties {
+			if _, ok := p.Default.ObjectVal[key]; !ok {
+				missingKeys = append(missingKeys, key)
+			}
+		}
+		if len(missingKeys) != 0 {
+			sort.Strings(missingKeys)
+			return &apis.FieldError{
+				Message: fmt.Sprintf("object default missing keys: %v", missingKeys),
+				Paths:   []string{p.Name + ".default"},
+			}
+		}
+	}
+
+	// Every per-key default must correspond to a declared property.
+	var errs *apis.FieldError
+	unknownKeys := []string{}
+	for key := range p.PropertyDefaults {
+		if _, ok := p.Properties[key]; !ok {
+			unknownKeys = append(unknownKeys, key)
+		}
+	}
+	sort.Strings(unknownKeys)
+	for _, key := range unknownKeys {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("unknown property default %q", key),
+			Paths:   []string{p.Name + ".propertyDefaults"},
+		})
+	}
+
+	return errs
 }
 
 // ValidateParameterVariables validates all variables within a slice of ParamSpecs against a slice of Steps
@@ -601,11 +1526,27 @@ func ValidateParameterVariables(ctx context.Context, steps []Step, params ParamS
 	var errs *apis.FieldError
 	errs = errs.Also(params.ValidateNoDuplicateNames())
 	errs = errs.Also(params.validateParamEnums(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamElementEnums(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamGroups().ViaField("params"))
+	errs = errs.Also(params.validateParamExamples().ViaField("params"))
+	errs = errs.Also(params.validateParamFormats(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamPatterns(ctx).ViaField("params"))
 	stringParams, arrayParams, objectParams := params.SortByType()
 	stringParameterNames := sets.NewString(stringParams.GetNames()...)
 	arrayParameterNames := sets.NewString(arrayParams.GetNames()...)
 	errs = errs.Also(ValidateNameFormat(stringParameterNames.Insert(arrayParameterNames.List()...), objectParams))
-	return errs.Also(validateArrayUsage(steps, "params", arrayParameterNames))
+	errs = errs.Also(validateArrayIndexSyntax(steps))
+	errs = errs.Also(validateObjectKeyIndexSyntax(steps, objectParams))
+	errs = errs.Also(validateArrayUsage(steps, "params", arrayParameterNames))
+	// Whole-object references are prohibited in string fields regardless of
+	// whether undeclared params are being propagated, so the declared object
+	// params can be checked here and not only from
+	// ValidateUsageOfDeclaredParameters.
+	objectParameterNames := sets.NewString()
+	for _, p := range objectParams {
+		objectParameterNames.Insert(p.Name)
+	}
+	return errs.Also(validateObjectUsageAsWhole(steps, "params", objectParameterNames))
 }
 
 // validateTaskContextVariables returns an error if any Steps reference context variables that don't exist.
@@ -630,13 +1571,146 @@ func validateTaskResultsVariables(ctx context.Context, steps []Step, results []T
 		resultsNames.Insert(r.Name)
 	}
 	for idx, step := range steps {
-		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(step.Script, "results", resultsNames).ViaField("script").ViaFieldIndex("steps", idx))
+		// References to keys of object results carry an extra dot-separated
+		// component that the generic check rejects, so validate them per result
+		// against the declared Properties and hide them from the generic check.
+		script := step.Script
+		for _, r := range results {
+			if r.Type != ResultsTypeObject {
+				continue
+			}
+			keys := sets.NewString("path")
+			for key := range r.Properties {
+				keys.Insert(key)
+			}
+			errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(step.Script, "results\\."+r.Name, keys).ViaField("script").ViaFieldIndex("steps", idx))
+			script = regexp.MustCompile(`\$\(results\.`+regexp.QuoteMeta(r.Name)+`\.[^)]+\)`).ReplaceAllString(script, "")
+		}
+		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(script, "results", resultsNames).ViaField("script").ViaFieldIndex("steps", idx))
+	}
+	return downgradeUnknownVariableErrs(ctx, errs)
+}
+
+// validateSidecarResultsVariables validates result references in sidecar fields.
+// Sidecars may read declared task results the same way steps do, but they are
+// not steps, so any $(step.results...) reference is rejected outright.
+func validateSidecarResultsVariables(ctx context.Context, sidecars []Sidecar, results []TaskResult) (errs *apis.FieldError) {
+	resultsNames := sets.NewString()
+	for _, r := range results {
+		resultsNames.Insert(r.Name)
+	}
+	for idx, sc := range sidecars {
+		errs = errs.Also(validateNoStepResultReference(sc.Script, "script").ViaFieldIndex("sidecars", idx))
+		for i, cmd := range sc.Command {
+			errs = errs.Also(validateNoStepResultReference(cmd, "").ViaFieldIndex("command", i).ViaFieldIndex("sidecars", idx))
+		}
+		for i, arg := range sc.Args {
+			errs = errs.Also(validateNoStepResultReference(arg, "").ViaFieldIndex("args", i).ViaFieldIndex("sidecars", idx))
+		}
+		for _, env := range sc.Env {
+			errs = errs.Also(validateNoStepResultReference(env.Value, "").ViaFieldKey("env", env.Name).ViaFieldIndex("sidecars", idx))
+		}
+		errs = errs.Also(downgradeUnknownVariableErrs(ctx, substitution.ValidateNoReferencesToUnknownVariables(sc.Script, "results", resultsNames).ViaField("script").ViaFieldIndex("sidecars", idx)))
+	}
+	return errs
+}
+
+func validateNoStepResultReference(value, path string) *apis.FieldError {
+	if strings.Contains(value, "$(step.results.") {
+		return apis.ErrGeneric("sidecars cannot reference step results", path)
+	}
+	return nil
+}
+
+// validateStepTaskResultCollisions rejects step results that share a name with
+// a task result, since references to the name silently resolve to the step
+// result. Teams that intentionally alias step results to task results can opt
+// out with the allow-step-task-result-overlap feature flag.
+func validateStepTaskResultCollisions(ctx context.Context, steps []Step, results []TaskResult) (errs *apis.FieldError) {
+	if config.FromContextOrDefaults(ctx).FeatureFlags.AllowStepTaskResultOverlap {
+		return nil
+	}
+	taskResultNames := sets.NewString()
+	for _, r := range results {
+		taskResultNames.Insert(r.Name)
+	}
+	for idx, step := range steps {
+		for j, sr := range step.Results {
+			if taskResultNames.Has(sr.Name) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("step result name %q collides with a task result name", sr.Name), "name").ViaIndex(j).ViaField("results").ViaFieldIndex("steps", idx))
+			}
+		}
+	}
+	return errs
+}
+
+// warnShadowedResultRefs warns when a step's script references a result name
+// that is declared both as a task result and as one of the step's results. The
+// step-level result takes precedence in that case, which can surprise authors
+// expecting to write the task result.
+func warnShadowedResultRefs(steps []Step, results []TaskResult) (errs *apis.FieldError) {
+	taskResultNames := sets.NewString()
+	for _, r := range results {
+		taskResultNames.Insert(r.Name)
+	}
+	for idx, step := range steps {
+		for _, sr := range step.Results {
+			if !taskResultNames.Has(sr.Name) {
+				continue
+			}
+			if strings.Contains(step.Script, fmt.Sprintf("$(results.%s.", sr.Name)) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result name %q is declared both as a task result and a step result; the step result takes precedence", sr.Name), "script").At(apis.WarningLevel).ViaFieldIndex("steps", idx))
+			}
+		}
+	}
+	return errs
+}
+
+// warnMixedEnvValueSources warns when a step and the stepTemplate define the
+// same env name but one layer uses Value and the other ValueFrom. The merge
+// keeps the step's definition, which can surprise authors expecting the
+// template's source to apply.
+func warnMixedEnvValueSources(steps []Step, stepTemplate *StepTemplate) (errs *apis.FieldError) {
+	if stepTemplate == nil {
+		return nil
+	}
+	templateEnv := map[string]corev1.EnvVar{}
+	for _, env := range stepTemplate.Env {
+		templateEnv[env.Name] = env
+	}
+	for idx, step := range steps {
+		for _, env := range step.Env {
+			tmpl, ok := templateEnv[env.Name]
+			if !ok {
+				continue
+			}
+			if (env.ValueFrom == nil) != (tmpl.ValueFrom == nil) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("env %q is defined by value in one of the step and stepTemplate and by valueFrom in the other", env.Name), "").ViaFieldKey("env", env.Name).At(apis.WarningLevel).ViaFieldIndex("steps", idx))
+			}
+		}
+	}
+	return errs
+}
+
+// warnScriptsMissingShebang warns when a step script does not start with a
+// shebang line, since such scripts silently run under a default interpreter
+// that may differ between platforms. Empty scripts and steps that use Command
+// instead are not affected.
+func warnScriptsMissingShebang(steps []Step) (errs *apis.FieldError) {
+	for idx, step := range steps {
+		trimmed := strings.TrimSpace(step.Script)
+		if trimmed == "" {
+			continue
+		}
+		if !strings.HasPrefix(trimmed, "#!") {
+			errs = errs.Also(apis.ErrGeneric("script does not begin with a shebang line, a default interpreter will be used", "script").At(apis.WarningLevel).ViaIndex(idx).ViaField("steps"))
+		}
 	}
 	return errs
 }
 
 // validateObjectUsage validates the usage of individual attributes of an object param and the usage of the entire object
-func validateObjectUsage(ctx context.Context, steps []Step, params []ParamSpec) (errs *apis.FieldError) {
+func validateObjectUsage(ctx context.Context, steps []Step, sidecars []Sidecar, params []ParamSpec) (errs *apis.FieldError) {
 	objectParameterNames := sets.NewString()
 	for _, p := range params {
 		// collect all names of object type params
@@ -650,6 +1724,7 @@ func validateObjectUsage(ctx context.Context, steps []Step, params []ParamSpec)
 
 		// check if the object's key names are referenced correctly i.e. param.objectParam.key1
 		errs = errs.Also(validateVariables(ctx, steps, "params\\."+p.Name, objectKeys))
+		errs = errs.Also(validateSidecarsVariables(sidecars, "params\\."+p.Name, objectKeys))
 	}
 
 	return errs.Also(validateObjectUsageAsWhole(steps, "params", objectParameterNames))
@@ -686,6 +1761,98 @@ func validateStepObjectUsageAsWhole(step Step, prefix string, vars sets.String)
 	return errs
 }
 
+// validateArrayIndexSyntax returns an error if the Steps reference an array param with an index that is not a non-negative integer or the star operator
+func validateArrayIndexSyntax(steps []Step) (errs *apis.FieldError) {
+	for idx, step := range steps {
+		errs = errs.Also(validateStepArrayIndexSyntax(step).ViaFieldIndex("steps", idx))
+	}
+	return errs
+}
+
+// validateObjectKeyIndexSyntax returns an error if the Steps reference an individual key of an object param using array index syntax when the key's property type is not an array
+func validateObjectKeyIndexSyntax(steps []Step, objectParams ParamSpecs) (errs *apis.FieldError) {
+	for idx, step := range steps {
+		errs = errs.Also(validateStepObjectKeyIndexSyntax(step, objectParams).ViaFieldIndex("steps", idx))
+	}
+	return errs
+}
+
+// validateStepObjectKeyIndexSyntax returns an error if the Step references an individual key of an object param using array index syntax when the key's property type is not an array
+func validateStepObjectKeyIndexSyntax(step Step, objectParams ParamSpecs) *apis.FieldError {
+	errs := errorIfObjectKeyIndexed(step.Name, objectParams).ViaField("name")
+	errs = errs.Also(errorIfObjectKeyIndexed(step.Image, objectParams).ViaField("image"))
+	errs = errs.Also(errorIfObjectKeyIndexed(step.WorkingDir, objectParams).ViaField("workingDir"))
+	errs = errs.Also(errorIfObjectKeyIndexed(step.Script, objectParams).ViaField("script"))
+	for i, cmd := range step.Command {
+		errs = errs.Also(errorIfObjectKeyIndexed(cmd, objectParams).ViaFieldIndex("command", i))
+	}
+	for i, arg := range step.Args {
+		errs = errs.Also(errorIfObjectKeyIndexed(arg, objectParams).ViaFieldIndex("args", i))
+	}
+	for _, env := range step.Env {
+		errs = errs.Also(errorIfObjectKeyIndexed(env.Value, objectParams).ViaFieldKey("env", env.Name))
+	}
+	return errs
+}
+
+// errorIfObjectKeyIndexed returns an error if the value references a declared object param key with array index syntax, e.g. "$(params.myObject.key[0])" where key is not an array property
+func errorIfObjectKeyIndexed(value string, objectParams ParamSpecs) (errs *apis.FieldError) {
+	for _, match := range objectKeyIndexRefRegex.FindAllStringSubmatch(value, -1) {
+		paramName, keyName := match[1], match[2]
+		for _, p := range objectParams {
+			if p.Name != paramName {
+				continue
+			}
+			if propertySpec, ok := p.Properties[keyName]; ok && propertySpec.Type != ParamTypeArray {
+				errs = errs.Also(&apis.FieldError{
+					Message: fmt.Sprintf("invalid reference syntax in %q", match[0]),
+					// Empty path is required to make the `ViaField`, … work
+					Paths: []string{""},
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// validateStepArrayIndexSyntax returns an error if the Step references an array param with an index that is not a non-negative integer or the star operator
+func validateStepArrayIndexSyntax(step Step) *apis.FieldError {
+	errs := errorIfInvalidArrayIndex(step.Name).ViaField("name")
+	errs = errs.Also(errorIfInvalidArrayIndex(step.Image).ViaField("image"))
+	errs = errs.Also(errorIfInvalidArrayIndex(step.WorkingDir).ViaField("workingDir"))
+	errs = errs.Also(errorIfInvalidArrayIndex(step.Script).ViaField("script"))
+	for i, cmd := range step.Command {
+		errs = errs.Also(errorIfInvalidArrayIndex(cmd).ViaFieldIndex("command", i))
+	}
+	for i, arg := range step.Args {
+		errs = errs.Also(errorIfInvalidArrayIndex(arg).ViaFieldIndex("args", i))
+	}
+	for _, env := range step.Env {
+		errs = errs.Also(errorIfInvalidArrayIndex(env.Value).ViaFieldKey("env", env.Name))
+	}
+	for i, v := range step.VolumeMounts {
+		errs = errs.Also(errorIfInvalidArrayIndex(v.Name).ViaField("name").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(errorIfInvalidArrayIndex(v.MountPath).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(errorIfInvalidArrayIndex(v.SubPath).ViaField("subPath").ViaFieldIndex("volumeMount", i))
+	}
+	return errs
+}
+
+// errorIfInvalidArrayIndex returns an error if the value contains an array indexing reference whose index is malformed, e.g. "$(params.arr[-1])" or "$(params.arr[abc])"
+func errorIfInvalidArrayIndex(value string) (errs *apis.FieldError) {
+	for _, ref := range arrayIndexRefRegex.FindAllString(value, -1) {
+		index := strings.TrimSuffix(strings.SplitN(ref, "[", 2)[1], "])")
+		if !validArrayIndexRegex.MatchString(index) {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("invalid array index in %q", ref),
+				// Empty path is required to make the `ViaField`, … work
+				Paths: []string{""},
+			})
+		}
+	}
+	return errs
+}
+
 // validateArrayUsage returns an error if the Steps contain references to the input array params in fields where these references are prohibited
 func validateArrayUsage(steps []Step, prefix string, arrayParamNames sets.String) (errs *apis.FieldError) {
 	for idx, step := range steps {
@@ -714,6 +1881,9 @@ func validateStepArrayUsage(step Step, prefix string, arrayParamNames sets.Strin
 		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(v.MountPath, prefix, arrayParamNames).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(v.SubPath, prefix, arrayParamNames).ViaField("subPath").ViaFieldIndex("volumeMount", i))
 	}
+	for i, e := range step.EnvFrom {
+		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(e.Prefix, prefix, arrayParamNames).ViaField("prefix").ViaFieldIndex("envFrom", i))
+	}
 	return errs
 }
 
@@ -722,6 +1892,17 @@ func validateVariables(ctx context.Context, steps []Step, prefix string, vars se
 	for idx, step := range steps {
 		errs = errs.Also(validateStepVariables(ctx, step, prefix, vars).ViaFieldIndex("steps", idx))
 	}
+	return downgradeUnknownVariableErrs(ctx, errs)
+}
+
+// downgradeUnknownVariableErrs downgrades non-existent-variable findings to
+// warning level when the unknown-variables-as-warnings feature flag is
+// enabled, so work-in-progress tasks can still be applied in dev clusters.
+// Field paths and messages are unchanged; only the severity differs.
+func downgradeUnknownVariableErrs(ctx context.Context, errs *apis.FieldError) *apis.FieldError {
+	if config.FromContextOrDefaults(ctx).FeatureFlags.UnknownVariablesAsWarnings {
+		return errs.At(apis.WarningLevel)
+	}
 	return errs
 }
 
@@ -798,6 +1979,38 @@ func validateStepVariables(ctx context.Context, step Step, prefix string, vars s
 	return errs
 }
 
+func validateSidecarsVariables(sidecars []Sidecar, prefix string, vars sets.String) (errs *apis.FieldError) {
+	for idx, sc := range sidecars {
+		errs = errs.Also(validateSidecarVariables(sc, prefix, vars).ViaFieldIndex("sidecars", idx))
+	}
+	return errs
+}
+
+// validateSidecarVariables mirrors validateStepVariables for a sidecar so that
+// undeclared variable references in sidecar fields are reported the same way
+// as step references.
+func validateSidecarVariables(sc Sidecar, prefix string, vars sets.String) *apis.FieldError {
+	errs := substitution.ValidateNoReferencesToUnknownVariables(sc.Name, prefix, vars).ViaField("name")
+	errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(sc.Image, prefix, vars).ViaField("image"))
+	errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(sc.WorkingDir, prefix, vars).ViaField("workingDir"))
+	errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(sc.Script, prefix, vars).ViaField("script"))
+	for i, cmd := range sc.Command {
+		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(cmd, prefix, vars).ViaFieldIndex("command", i))
+	}
+	for i, arg := range sc.Args {
+		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(arg, prefix, vars).ViaFieldIndex("args", i))
+	}
+	for _, env := range sc.Env {
+		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(env.Value, prefix, vars).ViaFieldKey("env", env.Name))
+	}
+	for i, v := range sc.VolumeMounts {
+		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(v.Name, prefix, vars).ViaField("name").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(v.MountPath, prefix, vars).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(v.SubPath, prefix, vars).ViaField("subPath").ViaFieldIndex("volumeMount", i))
+	}
+	return errs
+}
+
 // isParamRefs attempts to check if a specified string looks like it contains any parameter reference
 // This is useful to make sure the specified value looks like a Parameter Reference before performing any strict validation
 func isParamRefs(s string) bool {
@@ -818,6 +2031,15 @@ func (ts *TaskSpec) GetIndexingReferencesToArrayParams() sets.String {
 		paramsRefs = append(paramsRefs, v.MountPath)
 	}
 	paramsRefs = append(paramsRefs, extractParamRefsFromSidecars(ts.Sidecars)...)
+	for _, r := range ts.Results {
+		if r.Value != nil {
+			paramsRefs = append(paramsRefs, r.Value.StringVal)
+			paramsRefs = append(paramsRefs, r.Value.ArrayVal...)
+			for _, v := range r.Value.ObjectVal {
+				paramsRefs = append(paramsRefs, v)
+			}
+		}
+	}
 	// extract all array indexing references, for example []{"$(params.array-params[1])"}
 	arrayIndexParamRefs := []string{}
 	for _, p := range paramsRefs {
@@ -826,8 +2048,77 @@ func (ts *TaskSpec) GetIndexingReferencesToArrayParams() sets.String {
 	return sets.NewString(arrayIndexParamRefs...)
 }
 
+// ContextVariables returns all of the $(context.*) variables referenced in the
+// Task, such as "context.task.name" or "context.taskRun.namespace", without
+// the surrounding $( ) wrapper. The same fields are scanned as for array
+// indexing references: steps, the step template, volumes, workspace mount
+// paths and sidecars.
+func (ts *TaskSpec) ContextVariables() sets.String {
+	// collect all the possible places to use context references
+	refs := []string{}
+	refs = append(refs, extractParamRefsFromSteps(ts.Steps)...)
+	refs = append(refs, extractParamRefsFromStepTemplate(ts.StepTemplate)...)
+	refs = append(refs, extractParamRefsFromVolumes(ts.Volumes)...)
+	for _, v := range ts.Workspaces {
+		refs = append(refs, v.MountPath)
+	}
+	refs = append(refs, extractParamRefsFromSidecars(ts.Sidecars)...)
+	contextVars := sets.NewString()
+	for _, ref := range refs {
+		for _, match := range contextVariableRegex.FindAllString(ref, -1) {
+			contextVars.Insert(strings.TrimSuffix(strings.TrimPrefix(match, "$("), ")"))
+		}
+	}
+	return contextVars
+}
+
+// MatchesContract checks the declared params against an expected param
+// contract, reporting params that are not part of the contract, contract
+// params that are not declared, and params declared with a different type
+// than the contract expects. All errors are reported under "params".
+func (ts *TaskSpec) MatchesContract(expected ParamSpecs) (errs *apis.FieldError) {
+	expectedTypes := map[string]ParamType{}
+	for _, p := range expected {
+		expectedTypes[p.Name] = effectiveParamType(p.Type)
+	}
+	declared := sets.NewString()
+	for _, p := range ts.Params {
+		declared.Insert(p.Name)
+		expectedType, ok := expectedTypes[p.Name]
+		if !ok {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param %q is not part of the expected contract", p.Name), "").ViaFieldKey("params", p.Name))
+			continue
+		}
+		if declaredType := effectiveParamType(p.Type); declaredType != expectedType {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param %q has type %s but the contract expects %s", p.Name, declaredType, expectedType), "").ViaFieldKey("params", p.Name))
+		}
+	}
+	for _, p := range expected {
+		if !declared.Has(p.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("missing param %q required by the contract", p.Name), "params"))
+		}
+	}
+	return errs
+}
+
+// effectiveParamType returns the type a param declaration resolves to, since
+// an unset type defaults to string.
+func effectiveParamType(t ParamType) ParamType {
+	if t == "" {
+		return ParamTypeString
+	}
+	return t
+}
+
 // ValidateStepResults validates that all of the declared StepResults are valid.
 func ValidateStepResults(ctx context.Context, results []StepResult) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for index, result := range results {
+		if seen.Has(result.Name) {
+			errs = errs.Also(apis.ErrGeneric("result appears more than once", "name").ViaIndex(index))
+		}
+		seen.Insert(result.Name)
+	}
 	for index, result := range results {
 		errs = errs.Also(result.Validate(ctx).ViaIndex(index))
 	}
@@ -17,6 +17,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -78,6 +80,58 @@ func TestTaskValidate(t *testing.T) {
 	}
 }
 
+func TestTaskValidateDeprecatedAnnotation(t *testing.T) {
+	validSpec := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Image:   "my-image",
+			Command: []string{"command"},
+		}},
+	}
+	tests := []struct {
+		name            string
+		t               *v1.Task
+		expectedWarning *apis.FieldError
+	}{{
+		name: "deprecated annotation present",
+		t: &v1.Task{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "task",
+				Annotations: map[string]string{
+					"tekton.dev/deprecated": "this task is deprecated, use other-task instead",
+				},
+			},
+			Spec: validSpec,
+		},
+		expectedWarning: &apis.FieldError{
+			Message: "this task is deprecated, use other-task instead",
+			Paths:   []string{"metadata"},
+		},
+	}, {
+		name: "no deprecated annotation",
+		t: &v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "task"},
+			Spec:       validSpec,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := tt.t.Validate(context.Background()).Filter(apis.WarningLevel)
+			if tt.expectedWarning == nil {
+				if warnings != nil {
+					t.Errorf("Task.Validate() = %v", warnings)
+				}
+				return
+			}
+			if warnings == nil {
+				t.Fatalf("Expected a warning, got nothing for %v", tt.t)
+			}
+			if d := cmp.Diff(tt.expectedWarning.Error(), warnings.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("Task.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestTaskSpecValidatePropagatedParamsAndWorkspaces(t *testing.T) {
 	type fields struct {
 		Params       []v1.ParamSpec
@@ -369,6 +423,21 @@ func TestTaskSpecValidate(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "valid step env name with param reference",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "env-name",
+			}},
+			Steps: []v1.Step{{
+				Image:   "my-image",
+				Command: []string{"command"},
+				Env: []corev1.EnvVar{{
+					Name:  "$(params.env-name)",
+					Value: "value",
+				}},
+			}},
+		},
 	}, {
 		name: "valid step with parameterized script",
 		fields: fields{
@@ -416,7 +485,7 @@ func TestTaskSpecValidate(t *testing.T) {
 			Workspaces: []v1.WorkspaceDeclaration{{
 				Name:        "foo-workspace",
 				Description: "my great workspace",
-				MountPath:   "some/path",
+				MountPath:   "/some/path",
 			}},
 		},
 	}, {
@@ -431,6 +500,55 @@ func TestTaskSpecValidate(t *testing.T) {
 				Description: "my great result",
 			}},
 		},
+	}, {
+		name: "valid step timeout",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "my-image",
+				Args:    []string{"arg"},
+				Timeout: &metav1.Duration{Duration: time.Minute},
+			}},
+		},
+	}, {
+		name: "zero step timeout is treated as unset",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "my-image",
+				Args:    []string{"arg"},
+				Timeout: &metav1.Duration{Duration: 0},
+			}},
+		},
+	}, {
+		name: "results names differing only by case",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "my-image",
+				Args:  []string{"arg"},
+			}},
+			Results: []v1.TaskResult{{
+				Name:        "MY-RESULT",
+				Description: "my great result",
+			}, {
+				Name:        "my-result",
+				Description: "my lowercase result",
+			}},
+		},
+	}, {
+		name: "object result key referenced in script",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:  "my-image",
+				Script: "echo -n 123 > $(results.repo.commit.path)",
+			}},
+			Results: []v1.TaskResult{{
+				Name: "repo",
+				Type: v1.ResultsTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {"string"},
+					"commit": {"string"},
+				},
+			}},
+		},
 	}, {
 		name: "valid result type string",
 		fields: fields{
@@ -558,247 +676,401 @@ func TestTaskSpecValidate(t *testing.T) {
 	}
 }
 
-func TestTaskSpecStepActionReferenceValidate(t *testing.T) {
+func TestTaskSpecValidateWithContextVars(t *testing.T) {
+	allowed := sets.NewString("context.taskRun.namespace")
 	tests := []struct {
-		name  string
-		Steps []v1.Step
+		name          string
+		Steps         []v1.Step
+		expectedError *apis.FieldError
 	}{{
-		name: "valid stepaction ref",
+		name: "allowed context variable",
 		Steps: []v1.Step{{
-			Name: "mystep",
-			Ref: &v1.Ref{
-				Name: "stepAction",
-			},
+			Image: "my-image",
+			Args:  []string{"arg"},
+			Script: `
+				#!/usr/bin/env bash
+				hello "$(context.taskRun.namespace)"`,
 		}},
 	}, {
-		name: "valid use of params with Ref",
+		name: "disallowed context variable",
 		Steps: []v1.Step{{
-			Ref: &v1.Ref{
-				Name: "stepAction",
-			},
-			Params: v1.Params{{
-				Name: "param",
-			}},
+			Image: "my-image",
+			Args:  []string{"arg"},
+			Script: `
+				#!/usr/bin/env bash
+				hello "$(context.taskRun.uid)"`,
 		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env bash\n\t\t\t\thello \"$(context.taskRun.uid)\""`,
+			Paths:   []string{"steps[0].script"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &v1.TaskSpec{
 				Steps: tt.Steps,
 			}
-			ctx := config.ToContext(context.Background(), &config.Config{
-				FeatureFlags: &config.FeatureFlags{
-					EnableStepActions: true,
-				},
-			})
+			ctx := context.Background()
 			ts.SetDefaults(ctx)
-			if err := ts.Validate(ctx); err != nil {
-				t.Errorf("TaskSpec.Validate() = %v", err)
+			err := ts.ValidateWithContextVars(ctx, allowed)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.ValidateWithContextVars() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.ValidateWithContextVars() errors diff %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
 
-func TestTaskValidateError(t *testing.T) {
-	type fields struct {
-		Params []v1.ParamSpec
-		Steps  []v1.Step
-	}
+func TestTaskSpecValidateSelfContained(t *testing.T) {
 	tests := []struct {
 		name          string
-		fields        fields
-		expectedError apis.FieldError
+		Params        []v1.ParamSpec
+		Steps         []v1.Step
+		expectedError *apis.FieldError
 	}{{
-		name: "inexistent param variable",
-		fields: fields{
-			Steps: []v1.Step{{
-				Name:  "mystep",
-				Image: "myimage",
-				Args:  []string{"--flag=$(params.inexistent)"},
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "--flag=$(params.inexistent)"`,
-			Paths:   []string{"spec.steps[0].args[0]"},
-		},
+		name: "self-contained task",
+		Params: []v1.ParamSpec{{
+			Name: "message",
+			Type: v1.ParamTypeString,
+		}},
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Args:  []string{"$(params.message)"},
+		}},
 	}, {
-		name: "object used in a string field",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "gitrepo",
-				Type: v1.ParamTypeObject,
-				Properties: map[string]v1.PropertySpec{
-					"url":    {},
-					"commit": {},
-				},
-			}},
-			Steps: []v1.Step{{
-				Name:       "do-the-clone",
-				Image:      "$(params.gitrepo)",
-				Args:       []string{"echo"},
-				WorkingDir: "/foo/bar/src/",
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo)"`,
-			Paths:   []string{"spec.steps[0].image"},
+		name: "task relying on propagated param",
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Args:  []string{"$(params.message)"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "$(params.message)"`,
+			Paths:   []string{"steps[0].args[0]"},
 		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: tt.Params,
+				Steps:  tt.Steps,
+			}
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			err := ts.ValidateSelfContained(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.ValidateSelfContained() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.ValidateSelfContained() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateStableOnly(t *testing.T) {
+	tests := []struct {
+		name          string
+		Workspaces    []v1.WorkspaceDeclaration
+		Steps         []v1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "stable-clean task",
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Args:  []string{"arg"},
+		}},
 	}, {
-		name: "object star used in a string field",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "gitrepo",
-				Type: v1.ParamTypeObject,
-				Properties: map[string]v1.PropertySpec{
-					"url":    {},
-					"commit": {},
-				},
-			}},
-			Steps: []v1.Step{{
-				Name:       "do-the-clone",
-				Image:      "$(params.gitrepo[*])",
-				Args:       []string{"echo"},
-				WorkingDir: "/foo/bar/src/",
+		name: "task using step workspaces",
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name: "data",
+		}},
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Workspaces: []v1.WorkspaceUsage{{
+				Name: "data",
 			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `step workspaces requires "enable-api-fields" feature gate to be "alpha" or "beta" but it is "stable"`,
+			Paths:   []string{""},
 		},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo[*])"`,
-			Paths:   []string{"spec.steps[0].image"},
-		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Workspaces: tt.Workspaces,
+				Steps:      tt.Steps,
+			}
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			err := ts.ValidateStableOnly(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.ValidateStableOnly() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.ValidateStableOnly() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateWarnings(t *testing.T) {
+	tests := []struct {
+		name            string
+		Params          []v1.ParamSpec
+		Steps           []v1.Step
+		expectedWarning *apis.FieldError
+	}{{
+		name: "all params used",
+		Params: []v1.ParamSpec{{
+			Name: "message",
+			Type: v1.ParamTypeString,
+		}},
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Args:  []string{"$(params.message)"},
+		}},
 	}, {
-		name: "object used in a field that can accept array type",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "gitrepo",
-				Type: v1.ParamTypeObject,
-				Properties: map[string]v1.PropertySpec{
-					"url":    {},
-					"commit": {},
-				},
-			}},
-			Steps: []v1.Step{{
-				Name:       "do-the-clone",
-				Image:      "myimage",
-				Args:       []string{"$(params.gitrepo)"},
-				WorkingDir: "/foo/bar/src/",
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo)"`,
-			Paths:   []string{"spec.steps[0].args[0]"},
-		},
+		name: "object param used via key",
+		Params: []v1.ParamSpec{{
+			Name:       "myObject",
+			Type:       v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{"key1": {}},
+		}},
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Args:  []string{"$(params.myObject.key1)"},
+		}},
 	}, {
-		name: "object star used in a field that can accept array type",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "gitrepo",
-				Type: v1.ParamTypeObject,
-				Properties: map[string]v1.PropertySpec{
-					"url":    {},
-					"commit": {},
-				},
-			}},
-			Steps: []v1.Step{{
-				Name:       "do-the-clone",
-				Image:      "some-git-image",
-				Args:       []string{"$(params.gitrepo[*])"},
-				WorkingDir: "/foo/bar/src/",
-			}},
+		name: "array param used via index",
+		Params: []v1.ParamSpec{{
+			Name: "myArray",
+			Type: v1.ParamTypeArray,
+		}},
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Args:  []string{"$(params.myArray[0])"},
+		}},
+	}, {
+		name: "declared param never used",
+		Params: []v1.ParamSpec{{
+			Name: "message",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "unused",
+			Type: v1.ParamTypeString,
+		}},
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Args:  []string{"$(params.message)"},
+		}},
+		expectedWarning: &apis.FieldError{
+			Message: `param "unused" is declared but not used`,
+			Paths:   []string{"params[unused]"},
 		},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo[*])"`,
-			Paths:   []string{"spec.steps[0].args[0]"},
+	}, {
+		name: "propagated param is not reported",
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Args:  []string{"$(params.propagated)"},
+		}},
+	}, {
+		name: "deprecated param in use",
+		Params: []v1.ParamSpec{{
+			Name:               "old-flag",
+			Type:               v1.ParamTypeString,
+			DeprecationMessage: "use new-flag instead",
+		}},
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Args:  []string{"$(params.old-flag)"},
+		}},
+		expectedWarning: &apis.FieldError{
+			Message: `param "old-flag" is deprecated: use new-flag instead`,
+			Paths:   []string{"params[old-flag]"},
 		},
 	}, {
-		name: "non-existent individual key of an object param is used in task step",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "gitrepo",
-				Type: v1.ParamTypeObject,
-				Properties: map[string]v1.PropertySpec{
-					"url":    {},
-					"commit": {},
-				},
-			}},
-			Steps: []v1.Step{{
-				Name:       "do-the-clone",
-				Image:      "some-git-image",
-				Args:       []string{"$(params.gitrepo.non-exist-key)"},
-				WorkingDir: "/foo/bar/src/",
-			}},
+		name: "deprecated param that is unused only warns about being unused",
+		Params: []v1.ParamSpec{{
+			Name:               "old-flag",
+			Type:               v1.ParamTypeString,
+			DeprecationMessage: "use new-flag instead",
+		}},
+		Steps: []v1.Step{{
+			Image: "my-image",
+		}},
+		expectedWarning: &apis.FieldError{
+			Message: `param "old-flag" is declared but not used`,
+			Paths:   []string{"params[old-flag]"},
 		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "$(params.gitrepo.non-exist-key)"`,
-			Paths:   []string{"spec.steps[0].args[0]"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: tt.Params,
+				Steps:  tt.Steps,
+			}
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			warnings := ts.ValidateWarnings(ctx).Filter(apis.WarningLevel)
+			if tt.expectedWarning == nil {
+				if warnings != nil {
+					t.Errorf("TaskSpec.ValidateWarnings() = %v", warnings)
+				}
+				return
+			}
+			if warnings == nil {
+				t.Fatalf("Expected a warning, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedWarning.Error(), warnings.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.ValidateWarnings() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepEnvFromPrefixWarning(t *testing.T) {
+	tests := []struct {
+		name            string
+		envFrom         []corev1.EnvFromSource
+		expectedWarning *apis.FieldError
+	}{{
+		name: "colliding prefixes",
+		envFrom: []corev1.EnvFromSource{{
+			Prefix: "FOO_",
+			ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "a-configmap"},
+			},
+		}, {
+			Prefix: "FOO_",
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "a-secret"},
+			},
+		}},
+		expectedWarning: &apis.FieldError{
+			Message: `multiple envFrom sources with same prefix "FOO_"`,
+			Paths:   []string{"steps[0].envFrom"},
 		},
 	}, {
-		name: "Inexistent param variable in volumeMount with existing",
-		fields: fields{
-			Params: []v1.ParamSpec{
-				{
-					Name:        "foo",
-					Description: "param",
-					Default:     v1.NewStructuredValues("default"),
-				},
+		name: "distinct prefixes",
+		envFrom: []corev1.EnvFromSource{{
+			Prefix: "FOO_",
+			ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "a-configmap"},
 			},
-			Steps: []v1.Step{{
-				Name:  "mystep",
-				Image: "myimage",
-				VolumeMounts: []corev1.VolumeMount{{
-					Name: "$(params.inexistent)-foo",
+		}, {
+			Prefix: "BAR_",
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "a-secret"},
+			},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image:   "my-image",
+					EnvFrom: tt.envFrom,
 				}},
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "$(params.inexistent)-foo"`,
-			Paths:   []string{"spec.steps[0].volumeMount[0].name"},
+			}
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			warnings := ts.Validate(ctx).Filter(apis.WarningLevel)
+			if tt.expectedWarning == nil {
+				if warnings != nil {
+					t.Errorf("TaskSpec.Validate() = %v", warnings)
+				}
+				return
+			}
+			if warnings == nil {
+				t.Fatalf("Expected a warning, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedWarning.Error(), warnings.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateRequireExplicitCommand(t *testing.T) {
+	tests := []struct {
+		name          string
+		configMap     map[string]string
+		steps         []v1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "step with command",
+		configMap: map[string]string{
+			"require-explicit-command": "true",
 		},
+		steps: []v1.Step{{
+			Image:   "my-image",
+			Command: []string{"echo"},
+		}},
 	}, {
-		name: "Inexistent param variable with existing",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:        "foo",
-				Description: "param",
-				Default:     v1.NewStructuredValues("default"),
-			}},
-			Steps: []v1.Step{{
-				Name:  "mystep",
-				Image: "myimage",
-				Args:  []string{"$(params.foo) && $(params.inexistent)"},
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "$(params.foo) && $(params.inexistent)"`,
-			Paths:   []string{"spec.steps[0].args[0]"},
+		name: "step with script",
+		configMap: map[string]string{
+			"require-explicit-command": "true",
 		},
+		steps: []v1.Step{{
+			Image:  "my-image",
+			Script: "echo hello",
+		}},
 	}, {
-		name: "invalid step - invalid onError usage - set to a parameter which does not exist in the task",
-		fields: fields{
-			Steps: []v1.Step{{
-				OnError: "$(params.CONTINUE)",
-				Image:   "image",
-				Args:    []string{"arg"},
-			}},
+		name: "step with neither command nor script",
+		configMap: map[string]string{
+			"require-explicit-command": "true",
 		},
-		expectedError: apis.FieldError{
-			Message: "non-existent variable in \"$(params.CONTINUE)\"",
-			Paths:   []string{"spec.steps[0].onError"},
+		steps: []v1.Step{{
+			Image: "my-image",
+		}},
+		expectedError: &apis.FieldError{
+			Message: "expected command or script to be set when require-explicit-command is enabled",
+			Paths:   []string{"steps[0].command"},
 		},
+	}, {
+		name:      "step with neither command nor script and flag disabled",
+		configMap: map[string]string{},
+		steps: []v1.Step{{
+			Image: "my-image",
+		}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			task := &v1.Task{
-				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
-				Spec: v1.TaskSpec{
-					Params: tt.fields.Params,
-					Steps:  tt.fields.Steps,
-				},
+			ts := &v1.TaskSpec{
+				Steps: tt.steps,
+			}
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, tt.configMap)
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
 			}
-			ctx := cfgtesting.EnableAlphaAPIFields(context.Background())
-			task.SetDefaults(ctx)
-			err := task.Validate(ctx)
 			if err == nil {
-				t.Fatalf("Expected an error, got nothing for %v", task)
+				t.Fatalf("Expected an error, got nothing for %v", ts)
 			}
 			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
 				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
@@ -807,640 +1079,1112 @@ func TestTaskValidateError(t *testing.T) {
 	}
 }
 
-func TestTaskSpecValidateError(t *testing.T) {
-	type fields struct {
-		Params       []v1.ParamSpec
-		Steps        []v1.Step
-		Volumes      []corev1.Volume
-		StepTemplate *v1.StepTemplate
-		Workspaces   []v1.WorkspaceDeclaration
-		Results      []v1.TaskResult
-	}
+func TestTaskSpecValidateRequireStepActionChecksum(t *testing.T) {
 	tests := []struct {
 		name          string
-		fields        fields
-		expectedError apis.FieldError
+		configMap     map[string]string
+		steps         []v1.Step
+		expectedError *apis.FieldError
 	}{{
-		name: "empty spec",
-		expectedError: apis.FieldError{
-			Message: `missing field(s)`,
-			Paths:   []string{"steps"},
+		name: "ref with checksum param",
+		configMap: map[string]string{
+			"enable-step-actions":         "true",
+			"require-stepaction-checksum": "true",
 		},
+		steps: []v1.Step{{
+			Ref: &v1.Ref{
+				ResolverRef: v1.ResolverRef{
+					Resolver: "git",
+					Params: v1.Params{{
+						Name:  "checksum",
+						Value: *v1.NewStructuredValues("sha256:deadbeef"),
+					}},
+				},
+			},
+		}},
 	}, {
-		name: "no step",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:        "validparam",
-				Type:        v1.ParamTypeString,
-				Description: "parameter",
-				Default:     v1.NewStructuredValues("default"),
-			}},
+		name: "ref without checksum param",
+		configMap: map[string]string{
+			"enable-step-actions":         "true",
+			"require-stepaction-checksum": "true",
 		},
-		expectedError: apis.FieldError{
-			Message: `missing field(s)`,
-			Paths:   []string{"steps"},
+		steps: []v1.Step{{
+			Ref: &v1.Ref{
+				Name: "stepAction",
+			},
+		}},
+		expectedError: &apis.FieldError{
+			Message: "expected checksum param is required on step refs when require-stepaction-checksum is enabled",
+			Paths:   []string{"steps[0].ref"},
 		},
 	}, {
-		name: "step script refers to nonexistent result",
-		fields: fields{
+		name: "ref without checksum param and flag disabled",
+		configMap: map[string]string{
+			"enable-step-actions": "true",
+		},
+		steps: []v1.Step{{
+			Ref: &v1.Ref{
+				Name: "stepAction",
+			},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: tt.steps,
+			}
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, tt.configMap)
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecStepActionReferenceValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		Steps []v1.Step
+	}{{
+		name: "valid stepaction ref",
+		Steps: []v1.Step{{
+			Name: "mystep",
+			Ref: &v1.Ref{
+				Name: "stepAction",
+			},
+		}},
+	}, {
+		name: "valid use of params with Ref",
+		Steps: []v1.Step{{
+			Ref: &v1.Ref{
+				Name: "stepAction",
+			},
+			Params: v1.Params{{
+				Name: "param",
+			}},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: tt.Steps,
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableStepActions: true,
+				},
+			})
+			ts.SetDefaults(ctx)
+			if err := ts.Validate(ctx); err != nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestTaskValidateError(t *testing.T) {
+	type fields struct {
+		Params []v1.ParamSpec
+		Steps  []v1.Step
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		expectedError apis.FieldError
+	}{{
+		name: "inexistent param variable",
+		fields: fields{
 			Steps: []v1.Step{{
-				Name:  "step-name",
-				Image: "my-image",
-				Script: `
-				#!/usr/bin/env bash
-				date | tee $(results.non-exist.path)`,
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"--flag=$(params.inexistent)"},
 			}},
-			Results: []v1.TaskResult{{Name: "a-result"}},
 		},
 		expectedError: apis.FieldError{
-			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env bash\n\t\t\t\tdate | tee $(results.non-exist.path)"`,
-			Paths:   []string{"steps[0].script"},
+			Message: `non-existent variable in "--flag=$(params.inexistent)"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
 		},
 	}, {
-		name: "invalid param name format",
+		name: "object used in a string field",
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:        "_validparam1",
-				Description: "valid param name format",
-			}, {
-				Name:        "valid_param2",
-				Description: "valid param name format",
-			}, {
-				Name:        "",
-				Description: "invalid param name format",
-			}, {
-				Name:        "a^b",
-				Description: "invalid param name format",
-			}, {
-				Name:        "0ab",
-				Description: "invalid param name format",
-			}, {
-				Name:        "f oo",
-				Description: "invalid param name format",
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
+			}},
+			Steps: []v1.Step{{
+				Name:       "do-the-clone",
+				Image:      "$(params.gitrepo)",
+				Args:       []string{"echo"},
+				WorkingDir: "/foo/bar/src/",
 			}},
-			Steps: validSteps,
 		},
 		expectedError: apis.FieldError{
-			Message: fmt.Sprintf("The format of following array and string variable names is invalid: %s", []string{"", "0ab", "a^b", "f oo"}),
-			Paths:   []string{"params"},
-			Details: "String/Array Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_), and dots (.)\nMust begin with a letter or an underscore (_)",
+			Message: `variable type invalid in "$(params.gitrepo)"`,
+			Paths:   []string{"spec.steps[0].image"},
 		},
 	}, {
-		name: "invalid object param format - object param name and key name shouldn't contain dots.",
+		name: "object star used in a string field",
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:        "invalid.name1",
-				Description: "object param name contains dots",
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
 				Properties: map[string]v1.PropertySpec{
-					"invalid.key1": {},
-					"mykey2":       {},
+					"url":    {},
+					"commit": {},
 				},
 			}},
-			Steps: validSteps,
+			Steps: []v1.Step{{
+				Name:       "do-the-clone",
+				Image:      "$(params.gitrepo[*])",
+				Args:       []string{"echo"},
+				WorkingDir: "/foo/bar/src/",
+			}},
 		},
 		expectedError: apis.FieldError{
-			Message: fmt.Sprintf("Object param name and key name format is invalid: %v", map[string][]string{
-				"invalid.name1": {"invalid.key1"},
-			}),
-			Paths:   []string{"params"},
-			Details: "Object Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_) \nMust begin with a letter or an underscore (_)",
+			Message: `variable type invalid in "$(params.gitrepo[*])"`,
+			Paths:   []string{"spec.steps[0].image"},
 		},
 	}, {
-		name: "duplicated param names",
+		name: "object used in a field that can accept array type",
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:        "foo",
-				Type:        v1.ParamTypeString,
-				Description: "parameter",
-				Default:     v1.NewStructuredValues("value1"),
-			}, {
-				Name:        "foo",
-				Type:        v1.ParamTypeString,
-				Description: "parameter",
-				Default:     v1.NewStructuredValues("value2"),
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
+			}},
+			Steps: []v1.Step{{
+				Name:       "do-the-clone",
+				Image:      "myimage",
+				Args:       []string{"$(params.gitrepo)"},
+				WorkingDir: "/foo/bar/src/",
 			}},
-			Steps: validSteps,
 		},
 		expectedError: apis.FieldError{
-			Message: `parameter appears more than once`,
-			Paths:   []string{"params[foo]"},
+			Message: `variable type invalid in "$(params.gitrepo)"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
 		},
 	}, {
-		name: "invalid param type",
+		name: "object star used in a field that can accept array type",
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:        "validparam",
-				Type:        v1.ParamTypeString,
-				Description: "parameter",
-				Default:     v1.NewStructuredValues("default"),
-			}, {
-				Name:        "param-with-invalid-type",
-				Type:        "invalidtype",
-				Description: "invalidtypedesc",
-				Default:     v1.NewStructuredValues("default"),
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
+			}},
+			Steps: []v1.Step{{
+				Name:       "do-the-clone",
+				Image:      "some-git-image",
+				Args:       []string{"$(params.gitrepo[*])"},
+				WorkingDir: "/foo/bar/src/",
 			}},
-			Steps: validSteps,
 		},
 		expectedError: apis.FieldError{
-			Message: `invalid value: invalidtype`,
-			Paths:   []string{"params.param-with-invalid-type.type"},
+			Message: `variable type invalid in "$(params.gitrepo[*])"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
 		},
 	}, {
-		name: "param mismatching default/type 1",
+		name: "non-existent individual key of an object param is used in task step",
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:        "task",
-				Type:        v1.ParamTypeArray,
-				Description: "param",
-				Default:     v1.NewStructuredValues("default"),
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
+			}},
+			Steps: []v1.Step{{
+				Name:       "do-the-clone",
+				Image:      "some-git-image",
+				Args:       []string{"$(params.gitrepo.non-exist-key)"},
+				WorkingDir: "/foo/bar/src/",
 			}},
-			Steps: validSteps,
 		},
 		expectedError: apis.FieldError{
-			Message: `"array" type does not match default value's type: "string"`,
-			Paths:   []string{"params.task.type", "params.task.default.type"},
+			Message: `non-existent variable in "$(params.gitrepo.non-exist-key)"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
 		},
 	}, {
-		name: "param mismatching default/type 2",
+		name: "Inexistent param variable in volumeMount with existing",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:        "task",
-				Type:        v1.ParamTypeString,
-				Description: "param",
-				Default:     v1.NewStructuredValues("default", "array"),
+			Params: []v1.ParamSpec{
+				{
+					Name:        "foo",
+					Description: "param",
+					Default:     v1.NewStructuredValues("default"),
+				},
+			},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				VolumeMounts: []corev1.VolumeMount{{
+					Name: "$(params.inexistent)-foo",
+				}},
 			}},
-			Steps: validSteps,
 		},
 		expectedError: apis.FieldError{
-			Message: `"string" type does not match default value's type: "array"`,
-			Paths:   []string{"params.task.type", "params.task.default.type"},
-		},
+			Message: `non-existent variable in "$(params.inexistent)-foo"`,
+			Paths:   []string{"spec.steps[0].volumeMount[0].name"},
+		},
 	}, {
-		name: "param mismatching default/type 3",
+		name: "Inexistent param variable with existing",
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:        "task",
-				Type:        v1.ParamTypeArray,
+				Name:        "foo",
 				Description: "param",
-				Default: v1.NewObject(map[string]string{
-					"key1": "var1",
-					"key2": "var2",
-				}),
+				Default:     v1.NewStructuredValues("default"),
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(params.foo) && $(params.inexistent)"},
 			}},
-			Steps: validSteps,
 		},
 		expectedError: apis.FieldError{
-			Message: `"array" type does not match default value's type: "object"`,
-			Paths:   []string{"params.task.type", "params.task.default.type"},
+			Message: `non-existent variable in "$(params.foo) && $(params.inexistent)"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
 		},
 	}, {
-		name: "param mismatching default/type 4",
+		name: "invalid step - invalid onError usage - set to a parameter which does not exist in the task",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:        "task",
-				Type:        v1.ParamTypeObject,
-				Description: "param",
-				Properties:  map[string]v1.PropertySpec{"key1": {}},
-				Default:     v1.NewStructuredValues("var"),
+			Steps: []v1.Step{{
+				OnError: "$(params.CONTINUE)",
+				Image:   "image",
+				Args:    []string{"arg"},
 			}},
-			Steps: validSteps,
 		},
 		expectedError: apis.FieldError{
-			Message: `"object" type does not match default value's type: "string"`,
-			Paths:   []string{"params.task.type", "params.task.default.type"},
+			Message: "non-existent variable in \"$(params.CONTINUE)\"",
+			Paths:   []string{"spec.steps[0].onError"},
 		},
-	}, {
-		name: "PropertySpec type is set with unsupported type",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:        "task",
-				Type:        v1.ParamTypeObject,
-				Description: "param",
-				Properties: map[string]v1.PropertySpec{
-					"key1": {Type: "number"},
-					"key2": {Type: "string"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &v1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: v1.TaskSpec{
+					Params: tt.fields.Params,
+					Steps:  tt.fields.Steps,
 				},
-			}},
-			Steps: validSteps,
-		},
-		expectedError: apis.FieldError{
-			Message: fmt.Sprintf("The value type specified for these keys %v is invalid", []string{"key1"}),
-			Paths:   []string{"params.task.properties"},
+			}
+			ctx := cfgtesting.EnableAlphaAPIFields(context.Background())
+			task.SetDefaults(ctx)
+			err := task.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", task)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskValidatePartitioned(t *testing.T) {
+	tests := []struct {
+		name         string
+		task         *v1.Task
+		expectedMeta *apis.FieldError
+		expectedSpec *apis.FieldError
+	}{{
+		name: "valid task",
+		task: &v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "task"},
+			Spec: v1.TaskSpec{
+				Steps: validSteps,
+			},
 		},
 	}, {
-		name: "invalid step",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:        "validparam",
-				Type:        v1.ParamTypeString,
-				Description: "parameter",
-				Default:     v1.NewStructuredValues("default"),
-			}},
-			Steps: []v1.Step{},
+		name: "invalid metadata only",
+		task: &v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "invAlid,name"},
+			Spec: v1.TaskSpec{
+				Steps: validSteps,
+			},
 		},
-		expectedError: apis.FieldError{
-			Message: "missing field(s)",
-			Paths:   []string{"steps"},
+		expectedMeta: &apis.FieldError{
+			Message: `invalid resource name "invAlid,name": must be a valid DNS label`,
+			Paths:   []string{"metadata.name"},
 		},
 	}, {
-		name: "invalid step name",
-		fields: fields{
-			Steps: invalidSteps,
-		},
-		expectedError: apis.FieldError{
-			Message: `invalid value "replaceImage"`,
-			Paths:   []string{"steps[0].name"},
-			Details: "Task step name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		name: "invalid spec only",
+		task: &v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "task"},
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:   "mystep",
+					Image:  "myimage",
+					Script: "echo hello",
+					Args:   []string{"--flag=$(params.inexistent)"},
+				}},
+			},
 		},
-	}, {
-		name: "array used in unaccepted field",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "baz",
-				Type: v1.ParamTypeArray,
-			}, {
-				Name: "foo-is-baz",
-				Type: v1.ParamTypeArray,
-			}},
-			Steps: []v1.Step{{
-				Name:       "mystep",
-				Image:      "$(params.baz)",
-				Command:    []string{"$(params.foo-is-baz)"},
-				Args:       []string{"$(params.baz)", "middle string", "url"},
-				WorkingDir: "/foo/bar/src/",
-			}},
+		expectedSpec: &apis.FieldError{
+			Message: `non-existent variable in "--flag=$(params.inexistent)"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
 		},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.baz)"`,
-			Paths:   []string{"steps[0].image"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt.task.SetDefaults(ctx)
+			meta, spec := tt.task.ValidatePartitioned(ctx)
+			checkPartition := func(field string, want, got *apis.FieldError) {
+				t.Helper()
+				if want == nil {
+					if got != nil {
+						t.Errorf("Task.ValidatePartitioned() %s = %v", field, got)
+					}
+					return
+				}
+				if got == nil {
+					t.Fatalf("Expected a %s error, got nothing for %v", field, tt.task)
+				}
+				if d := cmp.Diff(want.Error(), got.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+					t.Errorf("Task.ValidatePartitioned() %s errors diff %s", field, diff.PrintWantGot(d))
+				}
+			}
+			checkPartition("meta", tt.expectedMeta, meta)
+			checkPartition("spec", tt.expectedSpec, spec)
+		})
+	}
+}
+
+func TestStepShadowedResultRefWarning(t *testing.T) {
+	tests := []struct {
+		name            string
+		script          string
+		stepResults     []v1.StepResult
+		expectedWarning *apis.FieldError
+	}{{
+		name:        "name declared in both namespaces and referenced",
+		script:      "echo -n foo > $(results.data.path)",
+		stepResults: []v1.StepResult{{Name: "data"}},
+		expectedWarning: &apis.FieldError{
+			Message: `result name "data" is declared both as a task result and a step result; the step result takes precedence`,
+			Paths:   []string{"steps[0].script"},
 		},
 	}, {
-		name: "array star used in unaccepted field",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "baz",
-				Type: v1.ParamTypeArray,
-			}, {
-				Name: "foo-is-baz",
-				Type: v1.ParamTypeArray,
-			}},
-			Steps: []v1.Step{{
-				Name:       "mystep",
-				Image:      "$(params.baz[*])",
-				Command:    []string{"$(params.foo-is-baz)"},
-				Args:       []string{"$(params.baz)", "middle string", "url"},
-				WorkingDir: "/foo/bar/src/",
-			}},
+		name:        "distinct names",
+		script:      "echo -n foo > $(results.data.path)",
+		stepResults: []v1.StepResult{{Name: "other"}},
+	}, {
+		name:        "shadowed name never referenced",
+		script:      "echo hello",
+		stepResults: []v1.StepResult{{Name: "data"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image:   "my-image",
+					Script:  tt.script,
+					Results: tt.stepResults,
+				}},
+				Results: []v1.TaskResult{{Name: "data"}, {Name: "other"}},
+			}
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+				"enable-step-actions":            "true",
+				"allow-step-task-result-overlap": "true",
+			})
+			ts.SetDefaults(ctx)
+			warnings := ts.Validate(ctx).Filter(apis.WarningLevel)
+			if tt.expectedWarning == nil {
+				if warnings != nil {
+					t.Errorf("TaskSpec.Validate() = %v", warnings)
+				}
+				return
+			}
+			if warnings == nil {
+				t.Fatalf("Expected a warning, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedWarning.Error(), warnings.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepTemplateMixedEnvValueSourcesWarning(t *testing.T) {
+	valueFrom := &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			Key:                  "A_KEY",
+			LocalObjectReference: corev1.LocalObjectReference{Name: "A_NAME"},
 		},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.baz[*])"`,
-			Paths:   []string{"steps[0].image"},
+	}
+	tests := []struct {
+		name            string
+		stepEnv         []corev1.EnvVar
+		templateEnv     []corev1.EnvVar
+		expectedWarning *apis.FieldError
+	}{{
+		name:        "both layers use value",
+		stepEnv:     []corev1.EnvVar{{Name: "FOO", Value: "step"}},
+		templateEnv: []corev1.EnvVar{{Name: "FOO", Value: "template"}},
+	}, {
+		name:        "both layers use valueFrom",
+		stepEnv:     []corev1.EnvVar{{Name: "FOO", ValueFrom: valueFrom}},
+		templateEnv: []corev1.EnvVar{{Name: "FOO", ValueFrom: valueFrom}},
+	}, {
+		name:        "distinct names",
+		stepEnv:     []corev1.EnvVar{{Name: "FOO", Value: "step"}},
+		templateEnv: []corev1.EnvVar{{Name: "BAR", ValueFrom: valueFrom}},
+	}, {
+		name:        "step value and template valueFrom",
+		stepEnv:     []corev1.EnvVar{{Name: "FOO", Value: "step"}},
+		templateEnv: []corev1.EnvVar{{Name: "FOO", ValueFrom: valueFrom}},
+		expectedWarning: &apis.FieldError{
+			Message: `env "FOO" is defined by value in one of the step and stepTemplate and by valueFrom in the other`,
+			Paths:   []string{"steps[0].env[FOO]"},
 		},
 	}, {
-		name: "array star used illegaly in script field",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "baz",
-				Type: v1.ParamTypeArray,
-			}, {
-				Name: "foo-is-baz",
-				Type: v1.ParamTypeArray,
-			}},
-			Steps: []v1.Step{
-				{
-					Script:     "$(params.baz[*])",
-					Name:       "mystep",
-					Image:      "my-image",
-					WorkingDir: "/foo/bar/src/",
-				},
-			},
+		name:        "step valueFrom and template value",
+		stepEnv:     []corev1.EnvVar{{Name: "FOO", ValueFrom: valueFrom}},
+		templateEnv: []corev1.EnvVar{{Name: "FOO", Value: "template"}},
+		expectedWarning: &apis.FieldError{
+			Message: `env "FOO" is defined by value in one of the step and stepTemplate and by valueFrom in the other`,
+			Paths:   []string{"steps[0].env[FOO]"},
 		},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.baz[*])"`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image:   "my-image",
+					Command: []string{"command"},
+					Env:     tt.stepEnv,
+				}},
+				StepTemplate: &v1.StepTemplate{
+					Env: tt.templateEnv,
+				},
+			}
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			warnings := ts.Validate(ctx).Filter(apis.WarningLevel)
+			if tt.expectedWarning == nil {
+				if warnings != nil {
+					t.Errorf("TaskSpec.Validate() = %v", warnings)
+				}
+				return
+			}
+			if warnings == nil {
+				t.Fatalf("Expected a warning, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedWarning.Error(), warnings.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepMixedResultPathFormsWarning(t *testing.T) {
+	tests := []struct {
+		name            string
+		script          string
+		expectedWarning *apis.FieldError
+	}{{
+		name:   "mixed forms for same result name",
+		script: "echo -n foo | tee $(results.data.path) $(step.results.data.path)",
+		expectedWarning: &apis.FieldError{
+			Message: `script writes both $(results.data.path) and $(step.results.data.path)`,
 			Paths:   []string{"steps[0].script"},
 		},
 	}, {
-		name: "array not properly isolated",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "baz",
-				Type: v1.ParamTypeArray,
-			}, {
-				Name: "foo-is-baz",
-				Type: v1.ParamTypeArray,
-			}},
-			Steps: []v1.Step{{
-				Name:       "mystep",
-				Image:      "someimage",
-				Command:    []string{"$(params.foo-is-baz)"},
-				Args:       []string{"not isolated: $(params.baz)", "middle string", "url"},
-				WorkingDir: "/foo/bar/src/",
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
-			Paths:   []string{"steps[0].args[0]"},
-		},
+		name:   "task-level form only",
+		script: "echo -n foo > $(results.data.path)",
 	}, {
-		name: "array star not properly isolated",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "baz",
-				Type: v1.ParamTypeArray,
-			}, {
-				Name: "foo-is-baz",
-				Type: v1.ParamTypeArray,
-			}},
-			Steps: []v1.Step{{
-				Name:       "mystep",
-				Image:      "someimage",
-				Command:    []string{"$(params.foo-is-baz)"},
-				Args:       []string{"not isolated: $(params.baz[*])", "middle string", "url"},
-				WorkingDir: "/foo/bar/src/",
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
-			Paths:   []string{"steps[0].args[0]"},
+		name:   "mixed forms for different result names",
+		script: "echo -n foo | tee $(results.data.path) $(step.results.other.path)",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image:  "my-image",
+					Script: tt.script,
+				}},
+				Results: []v1.TaskResult{{Name: "data"}},
+			}
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			warnings := ts.Validate(ctx).Filter(apis.WarningLevel)
+			if tt.expectedWarning == nil {
+				if warnings != nil {
+					t.Errorf("TaskSpec.Validate() = %v", warnings)
+				}
+				return
+			}
+			if warnings == nil {
+				t.Fatalf("Expected a warning, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedWarning.Error(), warnings.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepPreferStepResultsWarning(t *testing.T) {
+	tests := []struct {
+		name            string
+		script          string
+		configMap       map[string]string
+		expectedWarning *apis.FieldError
+	}{{
+		name:      "legacy result path with flag enabled",
+		script:    "echo -n foo > $(results.data.path)",
+		configMap: map[string]string{"prefer-step-results": "true"},
+		expectedWarning: &apis.FieldError{
+			Message: `legacy result path $(results.data.path) is used; prefer $(step.results.data.path)`,
+			Paths:   []string{"steps[0].script"},
 		},
 	}, {
-		name: "inferred array not properly isolated",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:    "baz",
-				Default: v1.NewStructuredValues("implied", "array", "type"),
-			}, {
-				Name:    "foo-is-baz",
-				Default: v1.NewStructuredValues("implied", "array", "type"),
-			}},
-			Steps: []v1.Step{{
-				Name:       "mystep",
-				Image:      "someimage",
-				Command:    []string{"$(params.foo-is-baz)"},
-				Args:       []string{"not isolated: $(params.baz)", "middle string", "url"},
-				WorkingDir: "/foo/bar/src/",
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
-			Paths:   []string{"steps[0].args[0]"},
-		},
+		name:      "step result path with flag enabled",
+		script:    "echo -n foo > $(step.results.data.path)",
+		configMap: map[string]string{"prefer-step-results": "true"},
 	}, {
-		name: "inferred array star not properly isolated",
-		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:    "baz",
-				Default: v1.NewStructuredValues("implied", "array", "type"),
-			}, {
-				Name:    "foo-is-baz",
-				Default: v1.NewStructuredValues("implied", "array", "type"),
-			}},
-			Steps: []v1.Step{{
-				Name:       "mystep",
-				Image:      "someimage",
-				Command:    []string{"$(params.foo-is-baz)"},
-				Args:       []string{"not isolated: $(params.baz[*])", "middle string", "url"},
-				WorkingDir: "/foo/bar/src/",
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
-			Paths:   []string{"steps[0].args[0]"},
-		},
+		name:   "legacy result path with flag disabled",
+		script: "echo -n foo > $(results.data.path)",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image:  "my-image",
+					Script: tt.script,
+				}},
+				Results: []v1.TaskResult{{Name: "data"}},
+			}
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, tt.configMap)
+			ts.SetDefaults(ctx)
+			warnings := ts.Validate(ctx).Filter(apis.WarningLevel)
+			if tt.expectedWarning == nil {
+				if warnings != nil {
+					t.Errorf("TaskSpec.Validate() = %v", warnings)
+				}
+				return
+			}
+			if warnings == nil {
+				t.Fatalf("Expected a warning, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedWarning.Error(), warnings.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateMaxStepsPerTask(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxStepsPerTask int
+		steps           []v1.Step
+		sidecars        []v1.Sidecar
+		expectedError   *apis.FieldError
+	}{{
+		name:            "within the limit",
+		maxStepsPerTask: 2,
+		steps:           []v1.Step{{Image: "my-image"}, {Image: "my-image"}},
+		sidecars:        []v1.Sidecar{{Name: "sc", Image: "my-image"}},
 	}, {
-		name: "Multiple volumes with same name",
-		fields: fields{
-			Steps: validSteps,
-			Volumes: []corev1.Volume{{
-				Name: "workspace",
-			}, {
-				Name: "workspace",
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `multiple volumes with same name "workspace"`,
-			Paths:   []string{"volumes[1].name"},
-		},
+		name:            "zero means no limit",
+		maxStepsPerTask: 0,
+		steps:           []v1.Step{{Image: "my-image"}, {Image: "my-image"}, {Image: "my-image"}},
 	}, {
-		name: "step with script and command",
-		fields: fields{
-			Steps: []v1.Step{{
-				Image:   "myimage",
-				Command: []string{"command"},
-				Script:  "script",
-			}},
+		name:            "too many steps",
+		maxStepsPerTask: 1,
+		steps:           []v1.Step{{Image: "my-image"}, {Image: "my-image"}},
+		expectedError: &apis.FieldError{
+			Message: "too many steps, max is 1",
+			Paths:   []string{"steps"},
 		},
-		expectedError: apis.FieldError{
-			Message: "script cannot be used with command",
-			Paths:   []string{"steps[0].script"},
+	}, {
+		name:            "too many sidecars",
+		maxStepsPerTask: 1,
+		steps:           []v1.Step{{Image: "my-image"}},
+		sidecars:        []v1.Sidecar{{Name: "sc1", Image: "my-image"}, {Name: "sc2", Image: "my-image"}},
+		expectedError: &apis.FieldError{
+			Message: "too many sidecars, max is 1",
+			Paths:   []string{"sidecars"},
 		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:    tt.steps,
+				Sidecars: tt.sidecars,
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{
+				Defaults: &config.Defaults{
+					MaxStepsPerTask: tt.maxStepsPerTask,
+				},
+				FeatureFlags: &config.FeatureFlags{},
+			})
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateRequirePinnedImages(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1.Step
+		sidecars      []v1.Sidecar
+		expectedError *apis.FieldError
+	}{{
+		name: "pinned step and sidecar images",
+		steps: []v1.Step{{
+			Image: "my-image@sha256:deedb0ed05f05b8a39b3a5ad43a4d2a17afb1d63a66b76a0f9a3adcc7d47e5f4",
+		}},
+		sidecars: []v1.Sidecar{{
+			Name:  "sc",
+			Image: "sidecar-image@sha256:deedb0ed05f05b8a39b3a5ad43a4d2a17afb1d63a66b76a0f9a3adcc7d47e5f4",
+		}},
 	}, {
-		name: "step volume mounts under /tekton/",
-		fields: fields{
-			Steps: []v1.Step{{
-				Image: "myimage",
-				VolumeMounts: []corev1.VolumeMount{{
-					Name:      "foo",
-					MountPath: "/tekton/foo",
-				}},
-			}},
+		name: "unpinned step image",
+		steps: []v1.Step{{
+			Image: "my-image:latest",
+		}},
+		expectedError: &apis.FieldError{
+			Message: `image "my-image:latest" must be pinned by digest when require-pinned-images is enabled`,
+			Paths:   []string{"steps[0].image"},
 		},
-		expectedError: apis.FieldError{
-			Message: `volumeMount cannot be mounted under /tekton/ (volumeMount "foo" mounted at "/tekton/foo")`,
-			Paths:   []string{"steps[0].volumeMounts[0].mountPath"},
+	}, {
+		name: "unpinned sidecar image",
+		steps: []v1.Step{{
+			Image: "my-image@sha256:deedb0ed05f05b8a39b3a5ad43a4d2a17afb1d63a66b76a0f9a3adcc7d47e5f4",
+		}},
+		sidecars: []v1.Sidecar{{
+			Name:  "sc",
+			Image: "sidecar-image:latest",
+		}},
+		expectedError: &apis.FieldError{
+			Message: `image "sidecar-image:latest" must be pinned by digest when require-pinned-images is enabled`,
+			Paths:   []string{"sidecars[0].image"},
 		},
 	}, {
-		name: "step volume mount name starts with tekton-internal-",
-		fields: fields{
-			Steps: []v1.Step{{
-				Image: "myimage",
-				VolumeMounts: []corev1.VolumeMount{{
-					Name:      "tekton-internal-foo",
-					MountPath: "/this/is/fine",
+		name: "param reference image is skipped",
+		steps: []v1.Step{{
+			Image: "$(params.image)",
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name: "image",
+					Type: v1.ParamTypeString,
 				}},
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `volumeMount name "tekton-internal-foo" cannot start with "tekton-internal-"`,
-			Paths:   []string{"steps[0].volumeMounts[0].name"},
-		},
+				Steps:    tt.steps,
+				Sidecars: tt.sidecars,
+			}
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+				"require-pinned-images": "true",
+			})
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateRequireImageDigestOrTag(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "tagged step image",
+		steps: []v1.Step{{
+			Image: "my-image:v1.2.3",
+		}},
 	}, {
-		name: "declared workspaces names are not unique",
-		fields: fields{
-			Steps: validSteps,
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name:      "same-workspace",
-				MountPath: "/foo",
-			}, {
-				Name:      "same-workspace",
-				MountPath: "/bar",
-			}},
+		name: "digested step image",
+		steps: []v1.Step{{
+			Image: "my-image@sha256:deedb0ed05f05b8a39b3a5ad43a4d2a17afb1d63a66b76a0f9a3adcc7d47e5f4",
+		}},
+	}, {
+		name: "untagged step image",
+		steps: []v1.Step{{
+			Image: "my-image",
+		}},
+		expectedError: &apis.FieldError{
+			Message: "image must specify a non-latest tag or digest",
+			Paths:   []string{"steps[0].image"},
 		},
-		expectedError: apis.FieldError{
-			Message: "workspace name \"same-workspace\" must be unique",
-			Paths:   []string{"workspaces[1].name"},
+	}, {
+		name: "latest step image",
+		steps: []v1.Step{{
+			Image: "my-image:latest",
+		}},
+		expectedError: &apis.FieldError{
+			Message: "image must specify a non-latest tag or digest",
+			Paths:   []string{"steps[0].image"},
 		},
 	}, {
-		name: "declared workspaces clash with each other",
-		fields: fields{
-			Steps: validSteps,
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name:      "some-workspace",
-				MountPath: "/foo",
-			}, {
-				Name:      "another-workspace",
-				MountPath: "/foo",
-			}},
+		name: "untagged image on a registry with a port",
+		steps: []v1.Step{{
+			Image: "registry.example.com:5000/my-image",
+		}},
+		expectedError: &apis.FieldError{
+			Message: "image must specify a non-latest tag or digest",
+			Paths:   []string{"steps[0].image"},
 		},
-		expectedError: apis.FieldError{
-			Message: "workspace mount path \"/foo\" must be unique",
-			Paths:   []string{"workspaces[1].mountpath"},
+	}, {
+		name: "param reference image is skipped",
+		steps: []v1.Step{{
+			Image: "$(params.image)",
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name: "image",
+					Type: v1.ParamTypeString,
+				}},
+				Steps: tt.steps,
+			}
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+				"require-image-digest-or-tag": "true",
+			})
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateReservedResultNames(t *testing.T) {
+	tests := []struct {
+		name          string
+		results       []v1.TaskResult
+		flagEnabled   bool
+		expectedError *apis.FieldError
+	}{{
+		name: "ordinary result name",
+		results: []v1.TaskResult{{
+			Name: "output",
+		}},
+		flagEnabled: true,
+	}, {
+		name: "reserved result name task",
+		results: []v1.TaskResult{{
+			Name: "task",
+		}},
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: `result name "task" is reserved`,
+			Paths:   []string{"results[0].name"},
 		},
 	}, {
-		name: "workspace mount path already in volumeMounts",
-		fields: fields{
+		name: "reserved result name taskRun at a later index",
+		results: []v1.TaskResult{{
+			Name: "output",
+		}, {
+			Name: "taskRun",
+		}},
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: `result name "taskRun" is reserved`,
+			Paths:   []string{"results[1].name"},
+		},
+	}, {
+		name: "reserved result name allowed with the flag disabled",
+		results: []v1.TaskResult{{
+			Name: "params",
+		}},
+		flagEnabled: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image: "my-image",
+				}},
+				Results: tt.results,
+			}
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+				"forbid-reserved-result-names": strconv.FormatBool(tt.flagEnabled),
+			})
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateVolumeMountRefs(t *testing.T) {
+	tests := []struct {
+		name          string
+		ts            *v1.TaskSpec
+		flagEnabled   bool
+		expectedError *apis.FieldError
+	}{{
+		name: "step volume mount references declared volume",
+		ts: &v1.TaskSpec{
 			Steps: []v1.Step{{
-				Image:   "myimage",
-				Command: []string{"command"},
+				Image: "my-image",
 				VolumeMounts: []corev1.VolumeMount{{
-					Name:      "my-mount",
-					MountPath: "/foo",
+					Name:      "data",
+					MountPath: "/data",
 				}},
 			}},
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name:      "some-workspace",
-				MountPath: "/foo",
+			Volumes: []corev1.Volume{{
+				Name: "data",
 			}},
 		},
-		expectedError: apis.FieldError{
-			Message: "workspace mount path \"/foo\" must be unique",
-			Paths:   []string{"workspaces[0].mountpath"},
-		},
+		flagEnabled: true,
 	}, {
-		name: "workspace default mount path already in volumeMounts",
-		fields: fields{
+		name: "step volume mount references declared workspace",
+		ts: &v1.TaskSpec{
 			Steps: []v1.Step{{
-				Image:   "myimage",
-				Command: []string{"command"},
+				Image: "my-image",
 				VolumeMounts: []corev1.VolumeMount{{
-					Name:      "my-mount",
-					MountPath: "/workspace/some-workspace/",
+					Name:      "ws",
+					MountPath: "/data",
 				}},
 			}},
 			Workspaces: []v1.WorkspaceDeclaration{{
-				Name: "some-workspace",
+				Name: "ws",
 			}},
 		},
-		expectedError: apis.FieldError{
-			Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
-			Paths:   []string{"workspaces[0].mountpath"},
+		flagEnabled: true,
+	}, {
+		name: "step volume mount name from a param is skipped",
+		ts: &v1.TaskSpec{
+			Params: []v1.ParamSpec{{
+				Name: "vol",
+				Type: v1.ParamTypeString,
+			}},
+			Steps: []v1.Step{{
+				Image: "my-image",
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "$(params.vol)",
+					MountPath: "/data",
+				}},
+			}},
 		},
+		flagEnabled: true,
 	}, {
-		name: "workspace mount path already in stepTemplate",
-		fields: fields{
-			StepTemplate: &v1.StepTemplate{
+		name: "step volume mount references unknown volume",
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image: "my-image",
 				VolumeMounts: []corev1.VolumeMount{{
 					Name:      "my-mount",
-					MountPath: "/foo",
+					MountPath: "/data",
 				}},
-			},
-			Steps: validSteps,
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name:      "some-workspace",
-				MountPath: "/foo",
 			}},
 		},
-		expectedError: apis.FieldError{
-			Message: "workspace mount path \"/foo\" must be unique",
-			Paths:   []string{"workspaces[0].mountpath"},
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: `volumeMount references unknown volume "my-mount"`,
+			Paths:   []string{"steps[0].volumeMounts[0].name"},
 		},
 	}, {
-		name: "workspace default mount path already in stepTemplate",
-		fields: fields{
+		name: "stepTemplate volume mount references unknown volume",
+		ts: &v1.TaskSpec{
 			StepTemplate: &v1.StepTemplate{
 				VolumeMounts: []corev1.VolumeMount{{
 					Name:      "my-mount",
-					MountPath: "/workspace/some-workspace",
+					MountPath: "/data",
 				}},
 			},
-			Steps: validSteps,
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name: "some-workspace",
+			Steps: []v1.Step{{
+				Image: "my-image",
 			}},
 		},
-		expectedError: apis.FieldError{
-			Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
-			Paths:   []string{"workspaces[0].mountpath"},
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: `volumeMount references unknown volume "my-mount"`,
+			Paths:   []string{"stepTemplate.volumeMounts[0].name"},
 		},
 	}, {
-		name: "result name not validate",
-		fields: fields{
-			Steps: validSteps,
-			Results: []v1.TaskResult{{
-				Name:        "MY^RESULT",
-				Description: "my great result",
+		name: "sidecar volume mount references unknown volume",
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image: "my-image",
 			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `invalid key name "MY^RESULT"`,
-			Paths:   []string{"results[0].name"},
-			Details: "Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')",
-		},
-	}, {
-		name: "result type not validate",
-		fields: fields{
-			Steps: validSteps,
-			Results: []v1.TaskResult{{
-				Name:        "MY-RESULT",
-				Type:        "wrong",
-				Description: "my great result",
+			Sidecars: []v1.Sidecar{{
+				Name:  "sc",
+				Image: "my-image",
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/data",
+				}},
 			}},
 		},
-		expectedError: apis.FieldError{
-			Message: `invalid value: wrong`,
-			Paths:   []string{"results[0].type"},
-			Details: "type must be string",
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: `volumeMount references unknown volume "my-mount"`,
+			Paths:   []string{"sidecars[0].volumeMounts[0].name"},
 		},
 	}, {
-		name: "context not validate",
-		fields: fields{
+		name: "unknown volume allowed with the flag disabled",
+		ts: &v1.TaskSpec{
 			Steps: []v1.Step{{
 				Image: "my-image",
-				Args:  []string{"arg"},
-				Script: `
-				#!/usr/bin/env  bash
-				hello "$(context.task.missing)"`,
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/data",
+				}},
 			}},
 		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env  bash\n\t\t\t\thello \"$(context.task.missing)\""`,
-			Paths:   []string{"steps[0].script"},
-		},
+		flagEnabled: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+				"require-declared-volume-mounts": strconv.FormatBool(tt.flagEnabled),
+			})
+			tt.ts.SetDefaults(ctx)
+			err := tt.ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", tt.ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateReservedParamNames(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        []v1.ParamSpec
+		flagEnabled   bool
+		expectedError *apis.FieldError
+	}{{
+		name: "similarly spelled param name is allowed",
+		params: []v1.ParamSpec{{
+			Name: "context-id",
+			Type: v1.ParamTypeString,
+		}},
+		flagEnabled: true,
 	}, {
-		name: "negative timeout string",
-		fields: fields{
-			Steps: []v1.Step{{
-				Timeout: &metav1.Duration{Duration: -10 * time.Second},
-			}},
+		name: "reserved param name context",
+		params: []v1.ParamSpec{{
+			Name: "context",
+			Type: v1.ParamTypeString,
+		}},
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: `param name "context" is reserved`,
+			Paths:   []string{"params"},
 		},
-		expectedError: apis.FieldError{
-			Message: "invalid value: -10s",
-			Paths:   []string{"steps[0].negative timeout"},
+	}, {
+		name: "reserved param name credentials",
+		params: []v1.ParamSpec{{
+			Name: "credentials",
+			Type: v1.ParamTypeString,
+		}},
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: `param name "credentials" is reserved`,
+			Paths:   []string{"params"},
 		},
+	}, {
+		name: "reserved param name allowed with the flag disabled",
+		params: []v1.ParamSpec{{
+			Name: "context",
+			Type: v1.ParamTypeString,
+		}},
+		flagEnabled: false,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ts := v1.TaskSpec{
-				Params:       tt.fields.Params,
-				Steps:        tt.fields.Steps,
-				Volumes:      tt.fields.Volumes,
-				StepTemplate: tt.fields.StepTemplate,
-				Workspaces:   tt.fields.Workspaces,
-				Results:      tt.fields.Results,
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image: "my-image",
+				}},
+				Params: tt.params,
 			}
-			ctx := cfgtesting.EnableAlphaAPIFields(context.Background())
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+				"forbid-reserved-param-names": strconv.FormatBool(tt.flagEnabled),
+			})
 			ts.SetDefaults(ctx)
 			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
 			if err == nil {
 				t.Fatalf("Expected an error, got nothing for %v", ts)
 			}
@@ -1451,214 +2195,131 @@ func TestTaskSpecValidateError(t *testing.T) {
 	}
 }
 
-func TestTaskSpecValidateErrorWithStepActionRef_CreateUpdateEvent(t *testing.T) {
+func TestTaskSpecValidateForVersion(t *testing.T) {
 	tests := []struct {
 		name          string
-		Steps         []v1.Step
-		isCreate      bool
-		isUpdate      bool
-		expectedError apis.FieldError
-	}{
-		{
-			name: "is create ctx",
-			Steps: []v1.Step{{
-				Ref: &v1.Ref{
-					Name: "stepAction",
-				},
-			}},
-			isCreate: true,
-			isUpdate: false,
-			expectedError: apis.FieldError{
-				Message: "feature flag enable-step-actions should be set to true to reference StepActions in Steps.",
-				Paths:   []string{"steps[0]"},
-			},
-		}, {
-			name: "is update ctx",
-			Steps: []v1.Step{{
-				Ref: &v1.Ref{
-					Name: "stepAction",
-				},
-			}},
-			isCreate: false,
-			isUpdate: true,
-			expectedError: apis.FieldError{
-				Message: "feature flag enable-step-actions should be set to true to reference StepActions in Steps.",
-				Paths:   []string{"steps[0]"},
-			},
-		}, {
-			name: "ctx is not create or update",
-			Steps: []v1.Step{{
-				Ref: &v1.Ref{
-					Name: "stepAction",
-				},
-			}},
-			isCreate:      false,
-			isUpdate:      false,
-			expectedError: apis.FieldError{},
+		version       string
+		expectedError *apis.FieldError
+	}{{
+		name:    "step results available at the target version",
+		version: "v0.54.0",
+	}, {
+		name:    "step results available at a newer version",
+		version: "v0.60.0",
+	}, {
+		name:    "step results unavailable at an older version",
+		version: "v0.50.0",
+		expectedError: &apis.FieldError{
+			Message: "step results requires Tekton version v0.54.0 or newer",
+			Paths:   []string{"steps[0].results"},
 		},
-	}
+	}, {
+		name:    "version without the v prefix",
+		version: "0.50.0",
+		expectedError: &apis.FieldError{
+			Message: "step results requires Tekton version v0.54.0 or newer",
+			Paths:   []string{"steps[0].results"},
+		},
+	}, {
+		name:    "unparseable version",
+		version: "garbage",
+		expectedError: &apis.FieldError{
+			Message: `invalid version "garbage"`,
+			Paths:   []string{""},
+		},
+	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ts := v1.TaskSpec{
-				Steps: tt.Steps,
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image: "my-image",
+					Results: []v1.StepResult{{
+						Name: "digest",
+					}},
+				}},
 			}
-			ctx := config.ToContext(context.Background(), &config.Config{
-				FeatureFlags: &config.FeatureFlags{
-					EnableStepActions: false,
-				},
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+				"enable-step-actions": "true",
 			})
-			if tt.isCreate {
-				ctx = apis.WithinCreate(ctx)
+			ts.SetDefaults(ctx)
+			err := ts.ValidateForVersion(ctx, tt.version)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.ValidateForVersion() = %v", err)
+				}
+				return
 			}
-			if tt.isUpdate {
-				ctx = apis.WithinUpdate(ctx, apis.GetBaseline(ctx))
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
 			}
-			ts.SetDefaults(ctx)
-			err := ts.Validate(ctx)
 			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
-				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+				t.Errorf("TaskSpec.ValidateForVersion() errors diff %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
 
-func TestTaskSpecValidateErrorWithStepActionRef(t *testing.T) {
+func TestTaskSpecValidateRequireCompleteObjectDefaults(t *testing.T) {
 	tests := []struct {
 		name          string
-		Steps         []v1.Step
-		expectedError apis.FieldError
-	}{
-		{
-			name: "Cannot use image with Ref",
-			Steps: []v1.Step{{
-				Ref: &v1.Ref{
-					Name: "stepAction",
-				},
-				Image: "foo",
-			}},
-			expectedError: apis.FieldError{
-				Message: "image cannot be used with Ref",
-				Paths:   []string{"steps[0].image"},
-			},
-		}, {
-			name: "Cannot use command with Ref",
-			Steps: []v1.Step{{
-				Ref: &v1.Ref{
-					Name: "stepAction",
-				},
-				Command: []string{"foo"},
-			}},
-			expectedError: apis.FieldError{
-				Message: "command cannot be used with Ref",
-				Paths:   []string{"steps[0].command"},
-			},
-		}, {
-			name: "Cannot use args with Ref",
-			Steps: []v1.Step{{
-				Ref: &v1.Ref{
-					Name: "stepAction",
-				},
-				Args: []string{"foo"},
-			}},
-			expectedError: apis.FieldError{
-				Message: "args cannot be used with Ref",
-				Paths:   []string{"steps[0].args"},
-			},
-		}, {
-			name: "Cannot use script with Ref",
-			Steps: []v1.Step{{
-				Ref: &v1.Ref{
-					Name: "stepAction",
-				},
-				Script: "echo hi",
-			}},
-			expectedError: apis.FieldError{
-				Message: "script cannot be used with Ref",
-				Paths:   []string{"steps[0].script"},
-			},
-		}, {
-			name: "Cannot use workingDir with Ref",
-			Steps: []v1.Step{{
-				Ref: &v1.Ref{
-					Name: "stepAction",
-				},
-				WorkingDir: "/workspace",
-			}},
-			expectedError: apis.FieldError{
-				Message: "working dir cannot be used with Ref",
-				Paths:   []string{"steps[0].workingDir"},
-			},
-		}, {
-			name: "Cannot use env with Ref",
-			Steps: []v1.Step{{
-				Ref: &v1.Ref{
-					Name: "stepAction",
-				},
-				Env: []corev1.EnvVar{{
-					Name:  "env1",
-					Value: "value1",
-				}},
-			}},
-			expectedError: apis.FieldError{
-				Message: "env cannot be used with Ref",
-				Paths:   []string{"steps[0].env"},
-			},
-		}, {
-			name: "Cannot use params without Ref",
-			Steps: []v1.Step{{
-				Image: "my-image",
-				Params: v1.Params{{
-					Name: "param",
-				}},
-			}},
-			expectedError: apis.FieldError{
-				Message: "params cannot be used without Ref",
-				Paths:   []string{"steps[0].params"},
-			},
-		}, {
-			name: "Cannot use volumeMounts with Ref",
-			Steps: []v1.Step{{
-				Ref: &v1.Ref{
-					Name: "stepAction",
-				},
-				VolumeMounts: []corev1.VolumeMount{{
-					Name:      "$(params.foo)",
-					MountPath: "/registry-config",
-				}},
-			}},
-			expectedError: apis.FieldError{
-				Message: "volumeMounts cannot be used with Ref",
-				Paths:   []string{"steps[0].volumeMounts"},
-			},
-		}, {
-			name: "Cannot use results with Ref",
-			Steps: []v1.Step{{
-				Ref: &v1.Ref{
-					Name: "stepAction",
-				},
-				Results: []v1.StepResult{{
-					Name: "result",
-				}},
-			}},
-			expectedError: apis.FieldError{
-				Message: "results cannot be used with Ref",
-				Paths:   []string{"steps[0].results"},
-			},
+		defaultValue  *v1.ParamValue
+		flagEnabled   bool
+		expectedError *apis.FieldError
+	}{{
+		name: "complete default with the flag enabled",
+		defaultValue: v1.NewObject(map[string]string{
+			"key1": "val1",
+			"key2": "val2",
+		}),
+		flagEnabled: true,
+	}, {
+		name:        "no default with the flag enabled",
+		flagEnabled: true,
+	}, {
+		name: "partial default with the flag enabled",
+		defaultValue: v1.NewObject(map[string]string{
+			"key1": "val1",
+		}),
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: "object default missing keys: [key2]",
+			Paths:   []string{"params.myobj.default"},
 		},
-	}
+	}, {
+		name: "partial default with the flag disabled",
+		defaultValue: v1.NewObject(map[string]string{
+			"key1": "val1",
+		}),
+		flagEnabled: false,
+	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ts := v1.TaskSpec{
-				Steps: tt.Steps,
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name: "myobj",
+					Type: v1.ParamTypeObject,
+					Properties: map[string]v1.PropertySpec{
+						"key1": {},
+						"key2": {},
+					},
+					Default: tt.defaultValue,
+				}},
+				Steps: []v1.Step{{
+					Image: "my-image",
+					Args:  []string{"$(params.myobj.key1)", "$(params.myobj.key2)"},
+				}},
 			}
-			ctx := config.ToContext(context.Background(), &config.Config{
-				FeatureFlags: &config.FeatureFlags{
-					EnableStepActions: true,
-				},
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+				"require-complete-object-defaults": strconv.FormatBool(tt.flagEnabled),
 			})
-			ctx = apis.WithinCreate(ctx)
 			ts.SetDefaults(ctx)
 			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
 			if err == nil {
 				t.Fatalf("Expected an error, got nothing for %v", ts)
 			}
@@ -1669,105 +2330,123 @@ func TestTaskSpecValidateErrorWithStepActionRef(t *testing.T) {
 	}
 }
 
-func TestTaskSpecValidateErrorWithStepResultRef(t *testing.T) {
-	tests := []struct {
-		name          string
-		Steps         []v1.Step
-		expectedError apis.FieldError
-	}{
-		{
-			name: "Cannot reference step results in image",
-			Steps: []v1.Step{{
-				Image: "$(steps.prevStep.results.resultName)",
-			}},
-			expectedError: apis.FieldError{
-				Message: "stepResult substitutions are only allowed in env, command and args. Found usage in",
-				Paths:   []string{"steps[0].image"},
-			},
+func TestParamGroups_Failure(t *testing.T) {
+	one := 1
+	two := 2
+	tcs := []struct {
+		name        string
+		params      v1.ParamSpecs
+		expectedErr error
+	}{{
+		name: "duplicate order within a group - failure",
+		params: []v1.ParamSpec{{
+			Name:  "param1",
+			Group: "image",
+			Order: &one,
 		}, {
-			name: "Cannot reference step results in script",
-			Steps: []v1.Step{{
-				Image:  "my-img",
-				Script: "echo $(steps.prevStep.results.resultName)",
-			}},
-			expectedError: apis.FieldError{
-				Message: "stepResult substitutions are only allowed in env, command and args. Found usage in",
-				Paths:   []string{"steps[0].script"},
-			},
+			Name:  "param2",
+			Group: "image",
+			Order: &one,
+		}},
+		expectedErr: errors.New(`order 1 appears more than once in group "image": params[param2].order`),
+	}, {
+		name: "duplicate order among ungrouped params - failure",
+		params: []v1.ParamSpec{{
+			Name:  "param1",
+			Order: &two,
 		}, {
-			name: "Cannot reference step results in workingDir",
-			Steps: []v1.Step{{
-				Image:      "my-img",
-				WorkingDir: "$(steps.prevStep.results.resultName)",
-			}},
-			expectedError: apis.FieldError{
-				Message: "stepResult substitutions are only allowed in env, command and args. Found usage in",
-				Paths:   []string{"steps[0].workingDir"},
-			},
-		}, {
-			name: "Cannot reference step results in envFrom",
-			Steps: []v1.Step{{
-				Image: "my-img",
-				EnvFrom: []corev1.EnvFromSource{{
-					Prefix: "$(steps.prevStep.results.resultName)",
-					ConfigMapRef: &corev1.ConfigMapEnvSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "$(steps.prevStep.results.resultName)",
-						},
-					},
-					SecretRef: &corev1.SecretEnvSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "$(steps.prevStep.results.resultName)",
-						},
-					},
-				}},
-			}},
-			expectedError: apis.FieldError{
-				Message: "stepResult substitutions are only allowed in env, command and args. Found usage in",
-				Paths:   []string{"steps[0].envFrom.configMapRef", "steps[0].envFrom.prefix", "steps[0].envFrom.secretRef"},
-			},
-		}, {
-			name: "Cannot reference step results in VolumeMounts",
-			Steps: []v1.Step{{
-				Image: "my-img",
-				VolumeMounts: []corev1.VolumeMount{{
-					Name:      "$(steps.prevStep.results.resultName)",
-					MountPath: "$(steps.prevStep.results.resultName)",
-					SubPath:   "$(steps.prevStep.results.resultName)",
-				}},
-			}},
-			expectedError: apis.FieldError{
-				Message: "stepResult substitutions are only allowed in env, command and args. Found usage in",
-				Paths:   []string{"steps[0].volumeMounts.name", "steps[0].volumeMounts.mountPath", "steps[0].volumeMounts.subPath"},
-			},
-		}, {
-			name: "Cannot reference step results in VolumeDevices",
-			Steps: []v1.Step{{
-				Image: "my-img",
-				VolumeDevices: []corev1.VolumeDevice{{
-					Name:       "$(steps.prevStep.results.resultName)",
-					DevicePath: "$(steps.prevStep.results.resultName)",
-				}},
-			}},
-			expectedError: apis.FieldError{
-				Message: "stepResult substitutions are only allowed in env, command and args. Found usage in",
-				Paths:   []string{"steps[0].volumeDevices.name", "steps[0].volumeDevices.devicePath"},
+			Name:  "param2",
+			Order: &two,
+		}},
+		expectedErr: errors.New(`order 2 appears more than once in group "": params[param2].order`),
+	}}
+
+	for _, tc := range tcs {
+		err := v1.ValidateParameterVariables(context.Background(), []v1.Step{{Image: "foo"}}, tc.params)
+
+		if err == nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		} else if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+			t.Errorf("Rerturned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestParamGroups_Success(t *testing.T) {
+	one := 1
+	params := v1.ParamSpecs{{
+		Name:  "param1",
+		Group: "image",
+		Order: &one,
+	}, {
+		Name:  "param2",
+		Group: "git",
+		Order: &one,
+	}, {
+		Name: "param3",
+	}}
+	if err := v1.ValidateParameterVariables(context.Background(), []v1.Step{{Image: "foo"}}, params); err != nil {
+		t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+	}
+}
+
+func TestTaskSpecValidateImageParamReferences(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        []v1.ParamSpec
+		image         string
+		expectedError *apis.FieldError
+	}{{
+		name: "string param in image",
+		params: []v1.ParamSpec{{
+			Name: "myimage",
+			Type: v1.ParamTypeString,
+		}},
+		image: "$(params.myimage)",
+	}, {
+		name: "array param in image",
+		params: []v1.ParamSpec{{
+			Name: "myimages",
+			Type: v1.ParamTypeArray,
+		}},
+		image: "$(params.myimages)",
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "$(params.myimages)"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "object param in image",
+		params: []v1.ParamSpec{{
+			Name: "myimage",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"name": {},
+				"tag":  {},
 			},
+		}},
+		image: "$(params.myimage)",
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "$(params.myimage)"`,
+			Paths:   []string{"steps[0].image"},
 		},
-	}
+	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ts := v1.TaskSpec{
-				Steps: tt.Steps,
+			ts := &v1.TaskSpec{
+				Params: tt.params,
+				Steps: []v1.Step{{
+					Image: tt.image,
+				}},
 			}
-			ctx := config.ToContext(context.Background(), &config.Config{
-				FeatureFlags: &config.FeatureFlags{
-					EnableStepActions: true,
-				},
-			})
-			ctx = apis.WithinCreate(ctx)
+			ctx := context.Background()
 			ts.SetDefaults(ctx)
 			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
 			if err == nil {
 				t.Fatalf("Expected an error, got nothing for %v", ts)
 			}
@@ -1777,188 +2456,168 @@ func TestTaskSpecValidateErrorWithStepResultRef(t *testing.T) {
 		})
 	}
 }
-func TestTaskSpecValidateSuccessWithArtifactsRefFlagEnabled(t *testing.T) {
+
+func TestTaskSpecValidateUnknownVariablesAsWarnings(t *testing.T) {
 	tests := []struct {
-		name  string
-		Steps []v1.Step
-	}{
-		{
-			name: "reference step artifacts in Env",
-			Steps: []v1.Step{{
-				Image: "busybox",
-				Env:   []corev1.EnvVar{{Name: "AAA", Value: "$(steps.aaa.outputs)"}},
-			}},
-		},
-		{
-			name: "reference step artifacts path in Env",
-			Steps: []v1.Step{{
-				Image: "busybox",
-				Env:   []corev1.EnvVar{{Name: "AAA", Value: "$(step.artifacts.path)"}},
-			}},
-		},
-		{
-			name: "reference step artifacts in Script",
-			Steps: []v1.Step{{
-				Image:  "busybox",
-				Script: "echo $(steps.aaa.inputs.bbb)",
-			}},
-		},
-		{
-			name: "reference step artifacts path in Script",
-			Steps: []v1.Step{{
-				Image:  "busybox",
-				Script: "echo 123 >> $(step.artifacts.path)",
-			}},
-		},
-		{
-			name: "reference step artifacts in Command",
-			Steps: []v1.Step{{
-				Image:   "busybox",
-				Command: []string{"echo", "$(steps.aaa.outputs.bbbb)"},
-			}},
-		},
-		{
-			name: "reference step artifacts path in Command",
-			Steps: []v1.Step{{
-				Image:   "busybox",
-				Command: []string{"echo", "$(step.artifacts.path)"},
-			}},
-		},
-		{
-			name: "reference step artifacts in Args",
-			Steps: []v1.Step{{
-				Image: "busybox",
-				Args:  []string{"echo", "$(steps.aaa.outputs.bbbb)"},
-			}},
-		},
-		{
-			name: "reference step artifacts path in Args",
-			Steps: []v1.Step{{
-				Image: "busybox",
-				Args:  []string{"echo", "$(step.artifacts.path)"},
-			}},
-		},
-	}
+		name        string
+		flagEnabled bool
+	}{{
+		name:        "unknown variable is an error by default",
+		flagEnabled: false,
+	}, {
+		name:        "unknown variable downgraded to a warning",
+		flagEnabled: true,
+	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ts := v1.TaskSpec{
-				Steps: tt.Steps,
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image: "my-image",
+					Args:  []string{"$(context.task.doesnotexist)"},
+				}},
 			}
-			ctx := config.ToContext(context.Background(), &config.Config{
-				FeatureFlags: &config.FeatureFlags{
-					EnableStepActions: true,
-					EnableArtifacts:   true,
-				},
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+				"unknown-variables-as-warnings": strconv.FormatBool(tt.flagEnabled),
 			})
-			ctx = apis.WithinCreate(ctx)
 			ts.SetDefaults(ctx)
 			err := ts.Validate(ctx)
-			if err != nil {
-				t.Fatalf("Expected no errors, got err for %v", err)
+			expectedError := &apis.FieldError{
+				Message: `non-existent variable in "$(context.task.doesnotexist)"`,
+				Paths:   []string{"steps[0].args[0]"},
+			}
+			if tt.flagEnabled {
+				if errorLevel := err.Filter(apis.ErrorLevel); errorLevel != nil {
+					t.Errorf("Expected no error-level findings with the flag enabled, got %v", errorLevel)
+				}
+				warnings := err.Filter(apis.WarningLevel)
+				if warnings == nil {
+					t.Fatalf("Expected a warning with the flag enabled, got nothing")
+				}
+				if d := cmp.Diff(expectedError.Error(), warnings.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+					t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+				}
+				return
+			}
+			errorLevel := err.Filter(apis.ErrorLevel)
+			if errorLevel == nil {
+				t.Fatalf("Expected an error with the flag disabled, got nothing")
+			}
+			if d := cmp.Diff(expectedError.Error(), errorLevel.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
-func TestTaskSpecValidateErrorWithArtifactsRefFlagNotEnabled(t *testing.T) {
+
+func TestTaskSpecValidityUnderFlags(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{
+			Name: "message",
+			Type: v1.ParamTypeString,
+			Enum: []string{"v1", "v2"},
+		}},
+		Steps: []v1.Step{{
+			Image: "my-image",
+		}},
+	}
+	ctx := context.Background()
+	ts.SetDefaults(ctx)
+
+	results := ts.ValidityUnderFlags(ctx, []map[string]string{
+		{"enable-param-enum": "true"},
+		{"enable-param-enum": "false"},
+	})
+
+	if err, ok := results["enable-param-enum=true"]; !ok {
+		t.Errorf("ValidityUnderFlags() missing result for enable-param-enum=true: %v", results)
+	} else if err != nil {
+		t.Errorf("ValidityUnderFlags() with enable-param-enum=true = %v", err)
+	}
+	err, ok := results["enable-param-enum=false"]
+	if !ok {
+		t.Fatalf("ValidityUnderFlags() missing result for enable-param-enum=false: %v", results)
+	}
+	if err == nil {
+		t.Fatalf("Expected an error with enable-param-enum=false, got nothing")
+	}
+	expectedErr := &apis.FieldError{
+		Message: "feature flag `enable-param-enum` should be set to true to use Enum",
+		Paths:   []string{"params[message]"},
+	}
+	if d := cmp.Diff(expectedErr.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+		t.Errorf("ValidityUnderFlags() errors diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskSpecValidateMalformedVariableRefs(t *testing.T) {
 	tests := []struct {
 		name          string
-		Steps         []v1.Step
-		expectedError apis.FieldError
-	}{
-		{
-			name: "Cannot reference step artifacts in Env without setting enable-artifacts to true",
-			Steps: []v1.Step{{
-				Env: []corev1.EnvVar{{Name: "AAA", Value: "$(steps.aaa.outputs)"}},
-			}},
-			expectedError: apis.FieldError{
-				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
-				Paths:   []string{"steps[0]"},
-			},
+		steps         []v1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "clean param reference",
+		steps: []v1.Step{{
+			Image: "my-image",
+			Args:  []string{"$(params.foo)"},
+		}},
+	}, {
+		name: "leading whitespace inside the reference",
+		steps: []v1.Step{{
+			Image: "my-image",
+			Args:  []string{"$( params.foo)"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: "malformed variable reference",
+			Paths:   []string{"steps[0].args[0]"},
+			Details: "$( params.foo)",
 		},
-		{
-			name: "Cannot reference step artifacts path in Env without setting enable-artifacts to true",
-			Steps: []v1.Step{{
-				Env: []corev1.EnvVar{{Name: "AAA", Value: "$(step.artifacts.path)"}},
-			}},
-			expectedError: apis.FieldError{
-				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
-				Paths:   []string{"steps[0]"},
-			},
+	}, {
+		name: "trailing whitespace inside the reference",
+		steps: []v1.Step{{
+			Image: "my-image",
+			Args:  []string{"$(params.foo )"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: "malformed variable reference",
+			Paths:   []string{"steps[0].args[0]"},
+			Details: "$(params.foo )",
 		},
-		{
-			name: "Cannot reference step artifacts in Script without setting enable-artifacts to true",
-			Steps: []v1.Step{{
-				Script: "echo $(steps.aaa.inputs.bbb)",
-			}},
-			expectedError: apis.FieldError{
-				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
-				Paths:   []string{"steps[0]"},
-			},
-		},
-		{
-			name: "Cannot reference step artifacts path in Script without setting enable-artifacts to true",
-			Steps: []v1.Step{{
-				Script: "echo 123 >> $(step.artifacts.path)",
-			}},
-			expectedError: apis.FieldError{
-				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
-				Paths:   []string{"steps[0]"},
-			},
-		},
-		{
-			name: "Cannot reference step artifacts in Command without setting enable-artifacts to true",
-			Steps: []v1.Step{{
-				Command: []string{"echo", "$(steps.aaa.outputs.bbbb)"},
-			}},
-			expectedError: apis.FieldError{
-				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
-				Paths:   []string{"steps[0]"},
-			},
-		},
-		{
-			name: "Cannot reference step artifacts path in Command without setting enable-artifacts to true",
-			Steps: []v1.Step{{
-				Command: []string{"echo", "$(step.artifacts.path)"},
-			}},
-			expectedError: apis.FieldError{
-				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
-				Paths:   []string{"steps[0]"},
-			},
-		},
-		{
-			name: "Cannot reference step artifacts in Args without setting enable-artifacts to true",
-			Steps: []v1.Step{{
-				Args: []string{"echo", "$(steps.aaa.outputs.bbbb)"},
-			}},
-			expectedError: apis.FieldError{
-				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
-				Paths:   []string{"steps[0]"},
-			},
-		},
-		{
-			name: "Cannot reference step artifacts path in Args without setting enable-artifacts to true",
-			Steps: []v1.Step{{
-				Args: []string{"echo", "$(step.artifacts.path)"},
-			}},
-			expectedError: apis.FieldError{
-				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
-				Paths:   []string{"steps[0]"},
-			},
+	}, {
+		name: "whitespace-padded reference in a script",
+		steps: []v1.Step{{
+			Image:  "my-image",
+			Script: "echo $( params.foo )",
+		}},
+		expectedError: &apis.FieldError{
+			Message: "malformed variable reference",
+			Paths:   []string{"steps[0].script"},
+			Details: "$( params.foo )",
 		},
-	}
+	}, {
+		name: "shell command substitution is not flagged",
+		steps: []v1.Step{{
+			Image:  "my-image",
+			Script: "echo $( date )",
+		}},
+	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ts := v1.TaskSpec{
-				Steps: tt.Steps,
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name: "foo",
+					Type: v1.ParamTypeString,
+				}},
+				Steps: tt.steps,
 			}
-			ctx := config.ToContext(context.Background(), &config.Config{
-				FeatureFlags: &config.FeatureFlags{
-					EnableStepActions: true,
-				},
-			})
-			ctx = apis.WithinCreate(ctx)
+			ctx := context.Background()
 			ts.SetDefaults(ctx)
 			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
 			if err == nil {
 				t.Fatalf("Expected an error, got nothing for %v", ts)
 			}
@@ -1969,96 +2628,100 @@ func TestTaskSpecValidateErrorWithArtifactsRefFlagNotEnabled(t *testing.T) {
 	}
 }
 
-func TestTaskSpecValidateErrorWithArtifactsRef(t *testing.T) {
+func TestTaskSpecValidateMaxDescriptionLength(t *testing.T) {
 	tests := []struct {
-		name          string
-		Steps         []v1.Step
-		expectedError apis.FieldError
+		name                 string
+		maxDescriptionLength int
+		ts                   *v1.TaskSpec
+		expectedError        *apis.FieldError
 	}{{
-		name: "Cannot reference step artifacts in image",
-		Steps: []v1.Step{{
-			Image: "$(steps.prevStep.outputs.aaa)",
-		}},
-		expectedError: apis.FieldError{
-			Message: "stepArtifact substitutions are only allowed in env, command, args and script. Found usage in",
-			Paths:   []string{"steps[0].image"},
+		name:                 "no limit configured",
+		maxDescriptionLength: 0,
+		ts: &v1.TaskSpec{
+			Steps: validSteps,
+			Params: []v1.ParamSpec{{
+				Name:        "myparam",
+				Description: strings.Repeat("x", 1000),
+			}},
 		},
-	},
-		{
-			name: "Cannot reference step artifacts in workingDir",
-			Steps: []v1.Step{{
-				Image:      "my-img",
-				WorkingDir: "$(steps.prevStep.outputs.aaa)",
+	}, {
+		name:                 "descriptions at the limit",
+		maxDescriptionLength: 10,
+		ts: &v1.TaskSpec{
+			Steps: validSteps,
+			Params: []v1.ParamSpec{{
+				Name:        "myparam",
+				Description: strings.Repeat("x", 10),
+			}},
+			Results: []v1.TaskResult{{
+				Name:        "myresult",
+				Description: strings.Repeat("x", 10),
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:        "myworkspace",
+				Description: strings.Repeat("x", 10),
 			}},
-			expectedError: apis.FieldError{
-				Message: "stepArtifact substitutions are only allowed in env, command, args and script. Found usage in",
-				Paths:   []string{"steps[0].workingDir"},
-			},
 		},
-		{
-			name: "Cannot reference step artifacts in envFrom",
-			Steps: []v1.Step{{
-				Image: "my-img",
-				EnvFrom: []corev1.EnvFromSource{{
-					Prefix: "$(steps.prevStep.outputs.aaa)",
-					ConfigMapRef: &corev1.ConfigMapEnvSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "$(steps.prevStep.outputs.aaa)",
-						},
-					},
-					SecretRef: &corev1.SecretEnvSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "$(steps.prevStep.outputs.aaa)",
-						},
-					},
-				}},
+	}, {
+		name:                 "param description over the limit",
+		maxDescriptionLength: 10,
+		ts: &v1.TaskSpec{
+			Steps: validSteps,
+			Params: []v1.ParamSpec{{
+				Name:        "myparam",
+				Description: strings.Repeat("x", 11),
 			}},
-			expectedError: apis.FieldError{
-				Message: "stepArtifact substitutions are only allowed in env, command, args and script. Found usage in",
-				Paths:   []string{"steps[0].envFrom.configMapRef", "steps[0].envFrom.prefix", "steps[0].envFrom.secretRef"},
-			},
-		}, {
-			name: "Cannot reference step artifacts in VolumeMounts",
-			Steps: []v1.Step{{
-				Image: "my-img",
-				VolumeMounts: []corev1.VolumeMount{{
-					Name:      "$(steps.prevStep.outputs.aaa)",
-					MountPath: "$(steps.prevStep.outputs.aaa)",
-					SubPath:   "$(steps.prevStep.outputs.aaa)",
-				}},
+		},
+		expectedError: &apis.FieldError{
+			Message: "description is 11 characters long, max is 10",
+			Paths:   []string{"params[0].description"},
+		},
+	}, {
+		name:                 "result description over the limit",
+		maxDescriptionLength: 10,
+		ts: &v1.TaskSpec{
+			Steps: validSteps,
+			Results: []v1.TaskResult{{
+				Name:        "myresult",
+				Description: strings.Repeat("x", 11),
 			}},
-			expectedError: apis.FieldError{
-				Message: "stepArtifact substitutions are only allowed in env, command, args and script. Found usage in",
-				Paths:   []string{"steps[0].volumeMounts.name", "steps[0].volumeMounts.mountPath", "steps[0].volumeMounts.subPath"},
-			},
-		}, {
-			name: "Cannot reference step artifacts in VolumeDevices",
-			Steps: []v1.Step{{
-				Image: "my-img",
-				VolumeDevices: []corev1.VolumeDevice{{
-					Name:       "$(steps.prevStep.outputs.aaa)",
-					DevicePath: "$(steps.prevStep.outputs.aaa)",
-				}},
+		},
+		expectedError: &apis.FieldError{
+			Message: "description is 11 characters long, max is 10",
+			Paths:   []string{"results[0].description"},
+		},
+	}, {
+		name:                 "workspace description over the limit",
+		maxDescriptionLength: 10,
+		ts: &v1.TaskSpec{
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:        "myworkspace",
+				Description: strings.Repeat("x", 11),
 			}},
-			expectedError: apis.FieldError{
-				Message: "stepArtifact substitutions are only allowed in env, command, args and script. Found usage in",
-				Paths:   []string{"steps[0].volumeDevices.name", "steps[0].volumeDevices.devicePath"},
-			},
 		},
-	}
+		expectedError: &apis.FieldError{
+			Message: "description is 11 characters long, max is 10",
+			Paths:   []string{"workspaces[0].description"},
+		},
+	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ts := v1.TaskSpec{
-				Steps: tt.Steps,
-			}
 			ctx := config.ToContext(context.Background(), &config.Config{
-				FeatureFlags: &config.FeatureFlags{
-					EnableStepActions: true,
+				Defaults: &config.Defaults{
+					MaxDescriptionLength: tt.maxDescriptionLength,
 				},
+				FeatureFlags: &config.FeatureFlags{},
 			})
-			ctx = apis.WithinCreate(ctx)
+			ts := tt.ts
 			ts.SetDefaults(ctx)
 			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
 			if err == nil {
 				t.Fatalf("Expected an error, got nothing for %v", ts)
 			}
@@ -2068,541 +2731,3608 @@ func TestTaskSpecValidateErrorWithArtifactsRef(t *testing.T) {
 		})
 	}
 }
-func TestTaskSpecValidateErrorSidecars(t *testing.T) {
+
+func TestTaskSpecValidateAllowedWorkspaceVolumeTypes(t *testing.T) {
+	ts := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Image: "my-image",
+		}},
+		Volumes: []corev1.Volume{{
+			Name: "pvc-volume",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "my-claim"},
+			},
+		}, {
+			Name: "emptydir-volume",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}},
+	}
+
+	// Without allowed volume types configured, all volume types are accepted.
+	if err := ts.Validate(context.Background()); err != nil {
+		t.Errorf("TaskSpec.Validate() = %v", err)
+	}
+
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{
+			AllowedWorkspaceVolumeTypes: []string{"persistentVolumeClaim"},
+		},
+		FeatureFlags: &config.FeatureFlags{},
+	})
+	err := ts.Validate(ctx)
+	if err == nil {
+		t.Fatalf("Expected an error, got nothing for %v", ts)
+	}
+	expectedError := apis.FieldError{
+		Message: `volume type "emptyDir" is not allowed`,
+		Paths:   []string{"volumes[1]"},
+	}
+	if d := cmp.Diff(expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+		t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskSpecValidateError(t *testing.T) {
+	type fields struct {
+		Params       []v1.ParamSpec
+		Steps        []v1.Step
+		Volumes      []corev1.Volume
+		StepTemplate *v1.StepTemplate
+		Workspaces   []v1.WorkspaceDeclaration
+		Results      []v1.TaskResult
+	}
 	tests := []struct {
 		name          string
-		sidecars      []v1.Sidecar
+		fields        fields
 		expectedError apis.FieldError
 	}{{
-		name: "missing image",
-		sidecars: []v1.Sidecar{{
-			Name: "tekton-invalid-side-car",
-		}},
+		name: "empty spec",
 		expectedError: apis.FieldError{
-			Message: "missing field(s)",
-			Paths:   []string{"sidecars.image"},
+			Message: `missing field(s)`,
+			Paths:   []string{"steps"},
 		},
 	}, {
-		name: "invalid command with script",
-		sidecars: []v1.Sidecar{{
-			Name:    "tekton-invalid-side-car",
-			Image:   "ubuntu",
-			Command: []string{"command foo"},
-			Script: `
-				#!/usr/bin/env  bash
-				echo foo`,
+		name: "no step",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "validparam",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewStructuredValues("default"),
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"steps"},
+		},
+	}, {
+		name: "step script refers to nonexistent result",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "step-name",
+				Image: "my-image",
+				Script: `
+				#!/usr/bin/env bash
+				date | tee $(results.non-exist.path)`,
+			}},
+			Results: []v1.TaskResult{{Name: "a-result"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env bash\n\t\t\t\tdate | tee $(results.non-exist.path)"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "step script refers to result with no results declared",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "step-name",
+				Image: "my-image",
+				Script: `
+				#!/usr/bin/env bash
+				date | tee $(results.non-exist.path)`,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env bash\n\t\t\t\tdate | tee $(results.non-exist.path)"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "invalid param name format",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "_validparam1",
+				Description: "valid param name format",
+			}, {
+				Name:        "valid_param2",
+				Description: "valid param name format",
+			}, {
+				Name:        "",
+				Description: "invalid param name format",
+			}, {
+				Name:        "a^b",
+				Description: "invalid param name format",
+			}, {
+				Name:        "0ab",
+				Description: "invalid param name format",
+			}, {
+				Name:        "f oo",
+				Description: "invalid param name format",
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: fmt.Sprintf("The format of following array and string variable names is invalid: %s", []string{"", "0ab", "a^b", "f oo"}),
+			Paths:   []string{"params"},
+			Details: "String/Array Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_), and dots (.)\nMust begin with a letter or an underscore (_)",
+		},
+	}, {
+		name: "invalid object param format - object param name and key name shouldn't contain dots.",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "invalid.name1",
+				Description: "object param name contains dots",
+				Properties: map[string]v1.PropertySpec{
+					"invalid.key1": {},
+					"mykey2":       {},
+				},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: fmt.Sprintf("Object param name and key name format is invalid: %v", map[string][]string{
+				"invalid.name1": {"invalid.key1"},
+			}),
+			Paths:   []string{"params"},
+			Details: "Object Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_) \nMust begin with a letter or an underscore (_)",
+		},
+	}, {
+		name: "duplicated param names",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "foo",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewStructuredValues("value1"),
+			}, {
+				Name:        "foo",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewStructuredValues("value2"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `parameter appears more than once`,
+			Paths:   []string{"params[foo]"},
+		},
+	}, {
+		name: "invalid param type",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "validparam",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewStructuredValues("default"),
+			}, {
+				Name:        "param-with-invalid-type",
+				Type:        "invalidtype",
+				Description: "invalidtypedesc",
+				Default:     v1.NewStructuredValues("default"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: invalidtype`,
+			Paths:   []string{"params.param-with-invalid-type.type"},
+		},
+	}, {
+		name: "param mismatching default/type 1",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeArray,
+				Description: "param",
+				Default:     v1.NewStructuredValues("default"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"array" type does not match default value's type: "string"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "param mismatching default/type 2",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeString,
+				Description: "param",
+				Default:     v1.NewStructuredValues("default", "array"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"string" type does not match default value's type: "array"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "param mismatching default/type 3",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeArray,
+				Description: "param",
+				Default: v1.NewObject(map[string]string{
+					"key1": "var1",
+					"key2": "var2",
+				}),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"array" type does not match default value's type: "object"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "param mismatching default/type 4",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeObject,
+				Description: "param",
+				Properties:  map[string]v1.PropertySpec{"key1": {}},
+				Default:     v1.NewStructuredValues("var"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"object" type does not match default value's type: "string"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "param mismatching default/type 5",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeObject,
+				Description: "param",
+				Properties:  map[string]v1.PropertySpec{"key1": {}},
+				Default:     v1.NewStructuredValues("var1", "var2"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"object" type does not match default value's type: "array"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "object param default with numeric value",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeObject,
+				Description: "param",
+				Properties:  map[string]v1.PropertySpec{"replicas": {}},
+				Default:     v1.NewStructuredValues(`{"replicas": 3}`),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `object default value for key replicas must be a string`,
+			Paths:   []string{"params[task].default"},
+		},
+	}, {
+		name: "PropertySpec type is set with unsupported type",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeObject,
+				Description: "param",
+				Properties: map[string]v1.PropertySpec{
+					"key1": {Type: "number"},
+					"key2": {Type: "string"},
+				},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: fmt.Sprintf("The value type specified for these keys %v is invalid", []string{"key1"}),
+			Paths:   []string{"params.task.properties"},
+		},
+	}, {
+		name: "invalid step",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "validparam",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewStructuredValues("default"),
+			}},
+			Steps: []v1.Step{},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"steps"},
+		},
+	}, {
+		name: "invalid step name",
+		fields: fields{
+			Steps: invalidSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value "replaceImage"`,
+			Paths:   []string{"steps[0].name"},
+			Details: "Task step name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		},
+	}, {
+		name: "array used in unaccepted field",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "$(params.baz)",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"$(params.baz)", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.baz)"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "array star used in unaccepted field",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "$(params.baz[*])",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"$(params.baz)", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.baz[*])"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "array star used illegaly in script field",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{
+				{
+					Script:     "$(params.baz[*])",
+					Name:       "mystep",
+					Image:      "my-image",
+					WorkingDir: "/foo/bar/src/",
+				},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.baz[*])"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "array not properly isolated",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "someimage",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"not isolated: $(params.baz)", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
+			Details: `array params must be used as a complete, isolated value like "$(params.baz[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "array star not properly isolated",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "someimage",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"not isolated: $(params.baz[*])", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
+			Details: `array params must be used as a complete, isolated value like "$(params.baz[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "inferred array not properly isolated",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:    "baz",
+				Default: v1.NewStructuredValues("implied", "array", "type"),
+			}, {
+				Name:    "foo-is-baz",
+				Default: v1.NewStructuredValues("implied", "array", "type"),
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "someimage",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"not isolated: $(params.baz)", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
+			Details: `array params must be used as a complete, isolated value like "$(params.baz[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "inferred array star not properly isolated",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:    "baz",
+				Default: v1.NewStructuredValues("implied", "array", "type"),
+			}, {
+				Name:    "foo-is-baz",
+				Default: v1.NewStructuredValues("implied", "array", "type"),
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "someimage",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"not isolated: $(params.baz[*])", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
+			Details: `array params must be used as a complete, isolated value like "$(params.baz[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "Multiple volumes with same name",
+		fields: fields{
+			Steps: validSteps,
+			Volumes: []corev1.Volume{{
+				Name: "workspace",
+			}, {
+				Name: "workspace",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `multiple volumes with same name "workspace"`,
+			Paths:   []string{"volumes[1].name"},
+		},
+	}, {
+		name: "step with script and command",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "myimage",
+				Command: []string{"command"},
+				Script:  "script",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "script cannot be used with command",
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "step volume mounts under /tekton/",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "myimage",
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "foo",
+					MountPath: "/tekton/foo",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `volumeMount cannot be mounted under /tekton/ (volumeMount "foo" mounted at "/tekton/foo")`,
+			Paths:   []string{"steps[0].volumeMounts[0].mountPath"},
+		},
+	}, {
+		name: "step volume mount name starts with tekton-internal-",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "myimage",
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "tekton-internal-foo",
+					MountPath: "/this/is/fine",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `volumeMount name "tekton-internal-foo" cannot start with "tekton-internal-"`,
+			Paths:   []string{"steps[0].volumeMounts[0].name"},
+		},
+	}, {
+		name: "declared workspaces names are not unique",
+		fields: fields{
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "same-workspace",
+				MountPath: "/foo",
+			}, {
+				Name:      "same-workspace",
+				MountPath: "/bar",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace name \"same-workspace\" must be unique",
+			Paths:   []string{"workspaces[1].name"},
+		},
+	}, {
+		name: "declared workspaces clash with each other",
+		fields: fields{
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "some-workspace",
+				MountPath: "/foo",
+			}, {
+				Name:      "another-workspace",
+				MountPath: "/foo",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/foo\" must be unique",
+			Paths:   []string{"workspaces[1].mountpath"},
+		},
+	}, {
+		name: "workspace mount path already in volumeMounts",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "myimage",
+				Command: []string{"command"},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/foo",
+				}},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "some-workspace",
+				MountPath: "/foo",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/foo\" must be unique",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "workspace default mount path already in volumeMounts",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "myimage",
+				Command: []string{"command"},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/workspace/some-workspace/",
+				}},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "some-workspace",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "workspace mount path already in stepTemplate",
+		fields: fields{
+			StepTemplate: &v1.StepTemplate{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/foo",
+				}},
+			},
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "some-workspace",
+				MountPath: "/foo",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/foo\" must be unique",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "workspace default mount path already in stepTemplate",
+		fields: fields{
+			StepTemplate: &v1.StepTemplate{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/workspace/some-workspace",
+				}},
+			},
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "some-workspace",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "invalid stepTemplate env name",
+		fields: fields{
+			StepTemplate: &v1.StepTemplate{
+				Env: []corev1.EnvVar{{
+					Name:  "BAD=NAME",
+					Value: "value",
+				}},
+			},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid environment variable name "BAD=NAME"`,
+			Paths:   []string{"stepTemplate.env[BAD=NAME]"},
+		},
+	}, {
+		name: "dotted stepTemplate env name",
+		fields: fields{
+			StepTemplate: &v1.StepTemplate{
+				Env: []corev1.EnvVar{{
+					Name:  "my.var",
+					Value: "value",
+				}},
+			},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid environment variable name "my.var"`,
+			Paths:   []string{"stepTemplate.env.[my.var]"},
+		},
+	}, {
+		name: "invalid step env name",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "my-image",
+				Command: []string{"command"},
+				Env: []corev1.EnvVar{{
+					Name:  "BAD-NAME",
+					Value: "value",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid environment variable name "BAD-NAME"`,
+			Paths:   []string{"steps[0].env[0].name"},
+		},
+	}, {
+		name: "workspace mount path contains result reference",
+		fields: fields{
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "some-workspace",
+				MountPath: "/mnt/$(results.x.path)",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "result references not allowed in mount path",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "workspace mount path is relative",
+		fields: fields{
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "some-workspace",
+				MountPath: "some/path",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `mount path must be absolute`,
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "result name not validate",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1.TaskResult{{
+				Name:        "MY^RESULT",
+				Description: "my great result",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid key name "MY^RESULT"`,
+			Paths:   []string{"results[0].name"},
+			Details: "Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')",
+		},
+	}, {
+		name: "result type not validate",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1.TaskResult{{
+				Name:        "MY-RESULT",
+				Type:        "wrong",
+				Description: "my great result",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: wrong`,
+			Paths:   []string{"results[0].type"},
+			Details: "type must be string",
+		},
+	}, {
+		name: "object result key does not exist in properties",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:  "my-image",
+				Script: "echo -n 123 > $(results.repo.badkey.path)",
+			}},
+			Results: []v1.TaskResult{{
+				Name: "repo",
+				Type: v1.ResultsTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {"string"},
+					"commit": {"string"},
+				},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "echo -n 123 > $(results.repo.badkey.path)"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "result appears more than once",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1.TaskResult{{
+				Name: "MY-RESULT",
+			}, {
+				Name: "MY-RESULT",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `result appears more than once`,
+			Paths:   []string{"results[1].name"},
+		},
+	}, {
+		name: "context not validate",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "my-image",
+				Args:  []string{"arg"},
+				Script: `
+				#!/usr/bin/env  bash
+				hello "$(context.task.missing)"`,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env  bash\n\t\t\t\thello \"$(context.task.missing)\""`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "negative timeout string",
+		fields: fields{
+			Steps: []v1.Step{{
+				Timeout: &metav1.Duration{Duration: -10 * time.Second},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "timeout must be greater than 0",
+			Paths:   []string{"steps[0].timeout"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := v1.TaskSpec{
+				Params:       tt.fields.Params,
+				Steps:        tt.fields.Steps,
+				Volumes:      tt.fields.Volumes,
+				StepTemplate: tt.fields.StepTemplate,
+				Workspaces:   tt.fields.Workspaces,
+				Results:      tt.fields.Results,
+			}
+			ctx := cfgtesting.EnableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateErrorWithStepActionRef_CreateUpdateEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		Steps         []v1.Step
+		isCreate      bool
+		isUpdate      bool
+		expectedError apis.FieldError
+	}{
+		{
+			name: "is create ctx",
+			Steps: []v1.Step{{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+			}},
+			isCreate: true,
+			isUpdate: false,
+			expectedError: apis.FieldError{
+				Message: "feature flag enable-step-actions should be set to true to reference StepActions in Steps.",
+				Paths:   []string{"steps[0]"},
+			},
+		}, {
+			name: "is update ctx",
+			Steps: []v1.Step{{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+			}},
+			isCreate: false,
+			isUpdate: true,
+			expectedError: apis.FieldError{
+				Message: "feature flag enable-step-actions should be set to true to reference StepActions in Steps.",
+				Paths:   []string{"steps[0]"},
+			},
+		}, {
+			name: "ctx is not create or update",
+			Steps: []v1.Step{{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+			}},
+			isCreate:      false,
+			isUpdate:      false,
+			expectedError: apis.FieldError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := v1.TaskSpec{
+				Steps: tt.Steps,
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableStepActions: false,
+				},
+			})
+			if tt.isCreate {
+				ctx = apis.WithinCreate(ctx)
+			}
+			if tt.isUpdate {
+				ctx = apis.WithinUpdate(ctx, apis.GetBaseline(ctx))
+			}
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateErrorWithStepActionRef(t *testing.T) {
+	tests := []struct {
+		name          string
+		Steps         []v1.Step
+		expectedError apis.FieldError
+	}{
+		{
+			name: "Cannot use image with Ref",
+			Steps: []v1.Step{{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+				Image: "foo",
+			}},
+			expectedError: apis.FieldError{
+				Message: "image cannot be used with Ref",
+				Paths:   []string{"steps[0].image"},
+			},
+		}, {
+			name: "Cannot use command with Ref",
+			Steps: []v1.Step{{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+				Command: []string{"foo"},
+			}},
+			expectedError: apis.FieldError{
+				Message: "command cannot be used with Ref",
+				Paths:   []string{"steps[0].command"},
+			},
+		}, {
+			name: "Cannot use args with Ref",
+			Steps: []v1.Step{{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+				Args: []string{"foo"},
+			}},
+			expectedError: apis.FieldError{
+				Message: "args cannot be used with Ref",
+				Paths:   []string{"steps[0].args"},
+			},
+		}, {
+			name: "Cannot use script with Ref",
+			Steps: []v1.Step{{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+				Script: "echo hi",
+			}},
+			expectedError: apis.FieldError{
+				Message: "script cannot be used with Ref",
+				Paths:   []string{"steps[0].script"},
+			},
+		}, {
+			name: "Cannot use workingDir with Ref",
+			Steps: []v1.Step{{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+				WorkingDir: "/workspace",
+			}},
+			expectedError: apis.FieldError{
+				Message: "working dir cannot be used with Ref",
+				Paths:   []string{"steps[0].workingDir"},
+			},
+		}, {
+			name: "Cannot use env with Ref",
+			Steps: []v1.Step{{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+				Env: []corev1.EnvVar{{
+					Name:  "env1",
+					Value: "value1",
+				}},
+			}},
+			expectedError: apis.FieldError{
+				Message: "env cannot be used with Ref",
+				Paths:   []string{"steps[0].env"},
+			},
+		}, {
+			name: "Cannot use params without Ref",
+			Steps: []v1.Step{{
+				Image: "my-image",
+				Params: v1.Params{{
+					Name: "param",
+				}},
+			}},
+			expectedError: apis.FieldError{
+				Message: "params cannot be used without Ref",
+				Paths:   []string{"steps[0].params"},
+			},
+		}, {
+			name: "Cannot use volumeMounts with Ref",
+			Steps: []v1.Step{{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "$(params.foo)",
+					MountPath: "/registry-config",
+				}},
+			}},
+			expectedError: apis.FieldError{
+				Message: "volumeMounts cannot be used with Ref",
+				Paths:   []string{"steps[0].volumeMounts"},
+			},
+		}, {
+			name: "Cannot use results with Ref",
+			Steps: []v1.Step{{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+				Results: []v1.StepResult{{
+					Name: "result",
+				}},
+			}},
+			expectedError: apis.FieldError{
+				Message: "results cannot be used with Ref",
+				Paths:   []string{"steps[0].results"},
+			},
+		}, {
+			name: "Cannot use timeout with Ref",
+			Steps: []v1.Step{{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+				Timeout: &metav1.Duration{Duration: time.Minute},
+			}},
+			expectedError: apis.FieldError{
+				Message: "timeout cannot be used with Ref",
+				Paths:   []string{"steps[0].timeout"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := v1.TaskSpec{
+				Steps: tt.Steps,
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableStepActions: true,
+				},
+			})
+			ctx = apis.WithinCreate(ctx)
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateErrorWithStepResultRef(t *testing.T) {
+	tests := []struct {
+		name          string
+		Steps         []v1.Step
+		expectedError apis.FieldError
+	}{
+		{
+			name: "Cannot reference step results in image",
+			Steps: []v1.Step{{
+				Image: "$(steps.prevStep.results.resultName)",
+			}},
+			expectedError: apis.FieldError{
+				Message: "stepResult substitutions are only allowed in env, command and args. Found usage in",
+				Paths:   []string{"steps[0].image"},
+			},
+		}, {
+			name: "Cannot reference step results in script",
+			Steps: []v1.Step{{
+				Image:  "my-img",
+				Script: "echo $(steps.prevStep.results.resultName)",
+			}},
+			expectedError: apis.FieldError{
+				Message: "stepResult substitutions are only allowed in env, command and args. Found usage in",
+				Paths:   []string{"steps[0].script"},
+			},
+		}, {
+			name: "Cannot reference step results in workingDir",
+			Steps: []v1.Step{{
+				Image:      "my-img",
+				WorkingDir: "$(steps.prevStep.results.resultName)",
+			}},
+			expectedError: apis.FieldError{
+				Message: "stepResult substitutions are only allowed in env, command and args. Found usage in",
+				Paths:   []string{"steps[0].workingDir"},
+			},
+		}, {
+			name: "Cannot reference step results in envFrom",
+			Steps: []v1.Step{{
+				Image: "my-img",
+				EnvFrom: []corev1.EnvFromSource{{
+					Prefix: "$(steps.prevStep.results.resultName)",
+					ConfigMapRef: &corev1.ConfigMapEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "$(steps.prevStep.results.resultName)",
+						},
+					},
+					SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "$(steps.prevStep.results.resultName)",
+						},
+					},
+				}},
+			}},
+			expectedError: apis.FieldError{
+				Message: "stepResult substitutions are only allowed in env, command and args. Found usage in",
+				Paths:   []string{"steps[0].envFrom.configMapRef", "steps[0].envFrom.prefix", "steps[0].envFrom.secretRef"},
+			},
+		}, {
+			name: "Cannot reference step results in VolumeMounts",
+			Steps: []v1.Step{{
+				Image: "my-img",
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "$(steps.prevStep.results.resultName)",
+					MountPath: "$(steps.prevStep.results.resultName)",
+					SubPath:   "$(steps.prevStep.results.resultName)",
+				}},
+			}},
+			expectedError: apis.FieldError{
+				Message: "stepResult substitutions are only allowed in env, command and args. Found usage in",
+				Paths:   []string{"steps[0].volumeMounts.name", "steps[0].volumeMounts.mountPath", "steps[0].volumeMounts.subPath"},
+			},
+		}, {
+			name: "Cannot reference step results in VolumeDevices",
+			Steps: []v1.Step{{
+				Image: "my-img",
+				VolumeDevices: []corev1.VolumeDevice{{
+					Name:       "$(steps.prevStep.results.resultName)",
+					DevicePath: "$(steps.prevStep.results.resultName)",
+				}},
+			}},
+			expectedError: apis.FieldError{
+				Message: "stepResult substitutions are only allowed in env, command and args. Found usage in",
+				Paths:   []string{"steps[0].volumeDevices.name", "steps[0].volumeDevices.devicePath"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := v1.TaskSpec{
+				Steps: tt.Steps,
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableStepActions: true,
+				},
+			})
+			ctx = apis.WithinCreate(ctx)
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+func TestTaskSpecValidateSuccessWithArtifactsRefFlagEnabled(t *testing.T) {
+	tests := []struct {
+		name  string
+		Steps []v1.Step
+	}{
+		{
+			name: "reference step artifacts in Env",
+			Steps: []v1.Step{{
+				Image: "busybox",
+				Env:   []corev1.EnvVar{{Name: "AAA", Value: "$(steps.aaa.outputs)"}},
+			}},
+		},
+		{
+			name: "reference step artifacts path in Env",
+			Steps: []v1.Step{{
+				Image: "busybox",
+				Env:   []corev1.EnvVar{{Name: "AAA", Value: "$(step.artifacts.path)"}},
+			}},
+		},
+		{
+			name: "reference step artifacts in Script",
+			Steps: []v1.Step{{
+				Image:  "busybox",
+				Script: "echo $(steps.aaa.inputs.bbb)",
+			}},
+		},
+		{
+			name: "reference step artifacts path in Script",
+			Steps: []v1.Step{{
+				Image:  "busybox",
+				Script: "echo 123 >> $(step.artifacts.path)",
+			}},
+		},
+		{
+			name: "reference step artifacts in Command",
+			Steps: []v1.Step{{
+				Image:   "busybox",
+				Command: []string{"echo", "$(steps.aaa.outputs.bbbb)"},
+			}},
+		},
+		{
+			name: "reference step artifacts path in Command",
+			Steps: []v1.Step{{
+				Image:   "busybox",
+				Command: []string{"echo", "$(step.artifacts.path)"},
+			}},
+		},
+		{
+			name: "reference step artifacts in Args",
+			Steps: []v1.Step{{
+				Image: "busybox",
+				Args:  []string{"echo", "$(steps.aaa.outputs.bbbb)"},
+			}},
+		},
+		{
+			name: "reference step artifacts path in Args",
+			Steps: []v1.Step{{
+				Image: "busybox",
+				Args:  []string{"echo", "$(step.artifacts.path)"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := v1.TaskSpec{
+				Steps: tt.Steps,
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableStepActions: true,
+					EnableArtifacts:   true,
+				},
+			})
+			ctx = apis.WithinCreate(ctx)
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err != nil {
+				t.Fatalf("Expected no errors, got err for %v", err)
+			}
+		})
+	}
+}
+func TestTaskSpecValidateErrorWithArtifactsRefFlagNotEnabled(t *testing.T) {
+	tests := []struct {
+		name          string
+		Steps         []v1.Step
+		expectedError apis.FieldError
+	}{
+		{
+			name: "Cannot reference step artifacts in Env without setting enable-artifacts to true",
+			Steps: []v1.Step{{
+				Env: []corev1.EnvVar{{Name: "AAA", Value: "$(steps.aaa.outputs)"}},
+			}},
+			expectedError: apis.FieldError{
+				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
+				Paths:   []string{"steps[0]"},
+			},
+		},
+		{
+			name: "Cannot reference step artifacts path in Env without setting enable-artifacts to true",
+			Steps: []v1.Step{{
+				Env: []corev1.EnvVar{{Name: "AAA", Value: "$(step.artifacts.path)"}},
+			}},
+			expectedError: apis.FieldError{
+				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
+				Paths:   []string{"steps[0]"},
+			},
+		},
+		{
+			name: "Cannot reference step artifacts in Script without setting enable-artifacts to true",
+			Steps: []v1.Step{{
+				Script: "echo $(steps.aaa.inputs.bbb)",
+			}},
+			expectedError: apis.FieldError{
+				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
+				Paths:   []string{"steps[0]"},
+			},
+		},
+		{
+			name: "Cannot reference step artifacts path in Script without setting enable-artifacts to true",
+			Steps: []v1.Step{{
+				Script: "echo 123 >> $(step.artifacts.path)",
+			}},
+			expectedError: apis.FieldError{
+				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
+				Paths:   []string{"steps[0]"},
+			},
+		},
+		{
+			name: "Cannot reference step artifacts in Command without setting enable-artifacts to true",
+			Steps: []v1.Step{{
+				Command: []string{"echo", "$(steps.aaa.outputs.bbbb)"},
+			}},
+			expectedError: apis.FieldError{
+				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
+				Paths:   []string{"steps[0]"},
+			},
+		},
+		{
+			name: "Cannot reference step artifacts path in Command without setting enable-artifacts to true",
+			Steps: []v1.Step{{
+				Command: []string{"echo", "$(step.artifacts.path)"},
+			}},
+			expectedError: apis.FieldError{
+				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
+				Paths:   []string{"steps[0]"},
+			},
+		},
+		{
+			name: "Cannot reference step artifacts in Args without setting enable-artifacts to true",
+			Steps: []v1.Step{{
+				Args: []string{"echo", "$(steps.aaa.outputs.bbbb)"},
+			}},
+			expectedError: apis.FieldError{
+				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
+				Paths:   []string{"steps[0]"},
+			},
+		},
+		{
+			name: "Cannot reference step artifacts path in Args without setting enable-artifacts to true",
+			Steps: []v1.Step{{
+				Args: []string{"echo", "$(step.artifacts.path)"},
+			}},
+			expectedError: apis.FieldError{
+				Message: fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts),
+				Paths:   []string{"steps[0]"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := v1.TaskSpec{
+				Steps: tt.Steps,
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableStepActions: true,
+				},
+			})
+			ctx = apis.WithinCreate(ctx)
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateErrorWithArtifactsRef(t *testing.T) {
+	tests := []struct {
+		name          string
+		Steps         []v1.Step
+		expectedError apis.FieldError
+	}{{
+		name: "Cannot reference step artifacts in image",
+		Steps: []v1.Step{{
+			Image: "$(steps.prevStep.outputs.aaa)",
+		}},
+		expectedError: apis.FieldError{
+			Message: "stepArtifact substitutions are only allowed in env, command, args and script. Found usage in",
+			Paths:   []string{"steps[0].image"},
+		},
+	},
+		{
+			name: "Cannot reference step artifacts in workingDir",
+			Steps: []v1.Step{{
+				Image:      "my-img",
+				WorkingDir: "$(steps.prevStep.outputs.aaa)",
+			}},
+			expectedError: apis.FieldError{
+				Message: "stepArtifact substitutions are only allowed in env, command, args and script. Found usage in",
+				Paths:   []string{"steps[0].workingDir"},
+			},
+		},
+		{
+			name: "Cannot reference step artifacts in envFrom",
+			Steps: []v1.Step{{
+				Image: "my-img",
+				EnvFrom: []corev1.EnvFromSource{{
+					Prefix: "$(steps.prevStep.outputs.aaa)",
+					ConfigMapRef: &corev1.ConfigMapEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "$(steps.prevStep.outputs.aaa)",
+						},
+					},
+					SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "$(steps.prevStep.outputs.aaa)",
+						},
+					},
+				}},
+			}},
+			expectedError: apis.FieldError{
+				Message: "stepArtifact substitutions are only allowed in env, command, args and script. Found usage in",
+				Paths:   []string{"steps[0].envFrom.configMapRef", "steps[0].envFrom.prefix", "steps[0].envFrom.secretRef"},
+			},
+		}, {
+			name: "Cannot reference step artifacts in VolumeMounts",
+			Steps: []v1.Step{{
+				Image: "my-img",
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "$(steps.prevStep.outputs.aaa)",
+					MountPath: "$(steps.prevStep.outputs.aaa)",
+					SubPath:   "$(steps.prevStep.outputs.aaa)",
+				}},
+			}},
+			expectedError: apis.FieldError{
+				Message: "stepArtifact substitutions are only allowed in env, command, args and script. Found usage in",
+				Paths:   []string{"steps[0].volumeMounts.name", "steps[0].volumeMounts.mountPath", "steps[0].volumeMounts.subPath"},
+			},
+		}, {
+			name: "Cannot reference step artifacts in VolumeDevices",
+			Steps: []v1.Step{{
+				Image: "my-img",
+				VolumeDevices: []corev1.VolumeDevice{{
+					Name:       "$(steps.prevStep.outputs.aaa)",
+					DevicePath: "$(steps.prevStep.outputs.aaa)",
+				}},
+			}},
+			expectedError: apis.FieldError{
+				Message: "stepArtifact substitutions are only allowed in env, command, args and script. Found usage in",
+				Paths:   []string{"steps[0].volumeDevices.name", "steps[0].volumeDevices.devicePath"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := v1.TaskSpec{
+				Steps: tt.Steps,
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableStepActions: true,
+				},
+			})
+			ctx = apis.WithinCreate(ctx)
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+func TestTaskSpecValidateErrorSidecars(t *testing.T) {
+	tests := []struct {
+		name          string
+		sidecars      []v1.Sidecar
+		expectedError apis.FieldError
+	}{{
+		name: "missing image",
+		sidecars: []v1.Sidecar{{
+			Name: "tekton-invalid-side-car",
+		}},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"sidecars.image"},
+		},
+	}, {
+		name: "invalid command with script",
+		sidecars: []v1.Sidecar{{
+			Name:    "tekton-invalid-side-car",
+			Image:   "ubuntu",
+			Command: []string{"command foo"},
+			Script: `
+				#!/usr/bin/env  bash
+				echo foo`,
+		}},
+		expectedError: apis.FieldError{
+			Message: "script cannot be used with command",
+			Paths:   []string{"sidecars.script"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:  "does-not-matter",
+					Image: "does-not-matter",
+				}},
+				Sidecars: tt.sidecars,
+			}
+			err := ts.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateSidecarResultRefs(t *testing.T) {
+	tests := []struct {
+		name          string
+		results       []v1.TaskResult
+		sidecars      []v1.Sidecar
+		expectedError *apis.FieldError
+	}{{
+		name:    "sidecar reads a declared task result",
+		results: []v1.TaskResult{{Name: "data"}},
+		sidecars: []v1.Sidecar{{
+			Name:   "watcher",
+			Image:  "my-image",
+			Script: "cat $(results.data.path)",
+		}},
+	}, {
+		name: "sidecar references an undeclared task result",
+		sidecars: []v1.Sidecar{{
+			Name:   "watcher",
+			Image:  "my-image",
+			Script: "cat $(results.missing.path)",
+		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "cat $(results.missing.path)"`,
+			Paths:   []string{"sidecars[0].script"},
+		},
+	}, {
+		name: "sidecar references a step result in script",
+		sidecars: []v1.Sidecar{{
+			Name:   "watcher",
+			Image:  "my-image",
+			Script: "cat $(step.results.data.path)",
+		}},
+		expectedError: &apis.FieldError{
+			Message: "sidecars cannot reference step results",
+			Paths:   []string{"sidecars[0].script"},
+		},
+	}, {
+		name: "sidecar references a step result in args",
+		sidecars: []v1.Sidecar{{
+			Name:  "watcher",
+			Image: "my-image",
+			Args:  []string{"$(step.results.data.path)"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: "sidecars cannot reference step results",
+			Paths:   []string{"sidecars[0].args[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:  "does-not-matter",
+					Image: "does-not-matter",
+				}},
+				Results:  tt.results,
+				Sidecars: tt.sidecars,
+			}
+			err := ts.Validate(context.Background())
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateErrorSidecarName(t *testing.T) {
+	tests := []struct {
+		name          string
+		sidecars      []v1.Sidecar
+		expectedError apis.FieldError
+	}{{
+		name: "cannot use reserved sidecar name",
+		sidecars: []v1.Sidecar{{
+			Name:  "tekton-log-results",
+			Image: "my-image",
+		}},
+		expectedError: apis.FieldError{
+			Message: fmt.Sprintf("Invalid: cannot use reserved sidecar name %v ", pipeline.ReservedResultsSidecarName),
+			Paths:   []string{"sidecars.name"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:  "does-not-matter",
+					Image: "does-not-matter",
+				}},
+				Sidecars: tt.sidecars,
+			}
+			err := ts.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepAndSidecarWorkspaces(t *testing.T) {
+	type fields struct {
+		Steps      []v1.Step
+		Sidecars   []v1.Sidecar
+		Workspaces []v1.WorkspaceDeclaration
+	}
+	tests := []struct {
+		name   string
+		fields fields
+	}{{
+		name: "valid step workspace usage",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "my-image",
+				Args:  []string{"arg"},
+				Workspaces: []v1.WorkspaceUsage{{
+					Name:      "foo-workspace",
+					MountPath: "/a/custom/mountpath",
+				}},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:        "foo-workspace",
+				Description: "my great workspace",
+				MountPath:   "/some/path",
+			}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:      tt.fields.Steps,
+				Sidecars:   tt.fields.Sidecars,
+				Workspaces: tt.fields.Workspaces,
+			}
+			ctx := cfgtesting.EnableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			if err := ts.Validate(ctx); err != nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestStepAndSidecarWorkspacesErrors(t *testing.T) {
+	type fields struct {
+		Steps      []v1.Step
+		Sidecars   []v1.Sidecar
+		Workspaces []v1.WorkspaceDeclaration
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		expectedError apis.FieldError
+	}{{
+		name: "step workspace that refers to non-existent workspace declaration fails",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "foo",
+				Workspaces: []v1.WorkspaceUsage{{
+					Name: "foo",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `undefined workspace "foo"`,
+			Paths:   []string{"steps[0].workspaces[0].name"},
+		},
+	}, {
+		name: "sidecar workspace that refers to non-existent workspace declaration fails",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "foo",
+			}},
+			Sidecars: []v1.Sidecar{{
+				Image: "foo",
+				Workspaces: []v1.WorkspaceUsage{{
+					Name: "foo",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `undefined workspace "foo"`,
+			Paths:   []string{"sidecars[0].workspaces[0].name"},
+		},
+	}, {
+		name: "step workspace that refers to an optional read-only workspace fails",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "foo",
+				Workspaces: []v1.WorkspaceUsage{{
+					Name: "foo",
+				}},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:     "foo",
+				Optional: true,
+				ReadOnly: true,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `optional workspace "foo" is read-only`,
+			Paths:   []string{"steps[0].workspaces[0].name"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:      tt.fields.Steps,
+				Sidecars:   tt.fields.Sidecars,
+				Workspaces: tt.fields.Workspaces,
+			}
+
+			ctx := cfgtesting.EnableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepOnError(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        []v1.ParamSpec
+		steps         []v1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "valid step - valid onError usage - set to continue",
+		steps: []v1.Step{{
+			OnError: v1.Continue,
+			Image:   "image",
+			Args:    []string{"arg"},
+		}},
+	}, {
+		name: "valid step - valid onError usage - set to stopAndFail",
+		steps: []v1.Step{{
+			OnError: v1.StopAndFail,
+			Image:   "image",
+			Args:    []string{"arg"},
+		}},
+	}, {
+		name: "valid step - valid onError usage - set to a task parameter",
+		params: []v1.ParamSpec{{
+			Name:    "CONTINUE",
+			Default: &v1.ParamValue{Type: v1.ParamTypeString, StringVal: string(v1.Continue)},
+		}},
+		steps: []v1.Step{{
+			OnError: "$(params.CONTINUE)",
+			Image:   "image",
+			Args:    []string{"arg"},
+		}},
+	}, {
+		name: "invalid step - onError set to invalid value",
+		steps: []v1.Step{{
+			OnError: "onError",
+			Image:   "image",
+			Args:    []string{"arg"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: "onError"`,
+			Paths:   []string{"steps[0].onError"},
+			Details: `Task step onError must be either "continue" or "stopAndFail"`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: tt.params,
+				Steps:  tt.steps,
+			}
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil && err != nil {
+				t.Errorf("No error expected from TaskSpec.Validate() but got = %v", err)
+			} else if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("Expected error from TaskSpec.Validate() = %v, but got none", tt.expectedError)
+				} else if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+					t.Errorf("returned error from TaskSpec.Validate() does not match with the expected error: %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
+// TestIncompatibleAPIVersions exercises validation of fields that
+// require a specific feature gate version in order to work.
+func TestIncompatibleAPIVersions(t *testing.T) {
+	versions := []string{"alpha", "beta", "stable"}
+	isStricterThen := func(first, second string) bool {
+		// assume values are in order alpha (less strict), beta, stable (strictest)
+		// return true if first is stricter then second
+		switch first {
+		case second, "alpha":
+			return false
+		case "stable":
+			return true
+		default:
+			// first is beta, true is second is alpha, false is second is stable
+			return second == "alpha"
+		}
+	}
+
+	for _, tt := range []struct {
+		name            string
+		requiredVersion string
+		spec            v1.TaskSpec
+	}{
+		{
+			name:            "step workspace requires beta",
+			requiredVersion: "beta",
+			spec: v1.TaskSpec{
+				Workspaces: []v1.WorkspaceDeclaration{{
+					Name: "foo",
+				}},
+				Steps: []v1.Step{{
+					Image: "foo",
+					Workspaces: []v1.WorkspaceUsage{{
+						Name: "foo",
+					}},
+				}},
+			},
+		}, {
+			name:            "sidecar workspace requires beta",
+			requiredVersion: "beta",
+			spec: v1.TaskSpec{
+				Workspaces: []v1.WorkspaceDeclaration{{
+					Name: "foo",
+				}},
+				Steps: []v1.Step{{
+					Image: "foo",
+				}},
+				Sidecars: []v1.Sidecar{{
+					Image: "foo",
+					Workspaces: []v1.WorkspaceUsage{{
+						Name: "foo",
+					}},
+				}},
+			},
+		}, {
+			name:            "windows script support requires alpha",
+			requiredVersion: "alpha",
+			spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image: "my-image",
+					Script: `
+				#!win powershell -File
+				script-1`,
+				}},
+			},
+		}, {
+			name:            "stdout stream support requires alpha",
+			requiredVersion: "alpha",
+			spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image: "foo",
+					StdoutConfig: &v1.StepOutputConfig{
+						Path: "/tmp/stdout.txt",
+					},
+				}},
+			},
+		}, {
+			name:            "stderr stream support requires alpha",
+			requiredVersion: "alpha",
+			spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image: "foo",
+					StderrConfig: &v1.StepOutputConfig{
+						Path: "/tmp/stderr.txt",
+					},
+				}},
+			},
+		},
+	} {
+		for _, version := range versions {
+			testName := fmt.Sprintf("(using %s) %s", version, tt.name)
+			t.Run(testName, func(t *testing.T) {
+				ts := tt.spec
+				ctx := context.Background()
+				if version == "alpha" {
+					ctx = cfgtesting.EnableAlphaAPIFields(ctx)
+				}
+				if version == "beta" {
+					ctx = cfgtesting.EnableBetaAPIFields(ctx)
+				}
+				if version == "stable" {
+					ctx = cfgtesting.EnableStableAPIFields(ctx)
+				}
+				ts.SetDefaults(ctx)
+				err := ts.Validate(ctx)
+
+				// If the configured version is stricter than the required one, we expect an error
+				if isStricterThen(version, tt.requiredVersion) && err == nil {
+					t.Fatalf("no error received even though version required is %q while feature gate is %q", tt.requiredVersion, version)
+				}
+
+				// If the configured version is more permissive than the required one, we expect no error
+				if isStricterThen(tt.requiredVersion, version) && err != nil {
+					t.Fatalf("error received despite required version and feature gate matching %q: %v", version, err)
+				}
+			})
+		}
+	}
+}
+
+func TestTaskSpecValidateUsageOfDeclaredParams(t *testing.T) {
+	tests := []struct {
+		name          string
+		Params        []v1.ParamSpec
+		Steps         []v1.Step
+		Sidecars      []v1.Sidecar
+		expectedError apis.FieldError
+	}{{
+		name: "inexistent param variable in sidecar",
+		Sidecars: []v1.Sidecar{{
+			Name:  "mysidecar",
+			Image: "myimage",
+			Args:  []string{"--flag=$(params.inexistent)"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "--flag=$(params.inexistent)"`,
+			Paths:   []string{"sidecars[0].args[0]"},
+		},
+	}, {
+		name: "non-existent individual key of an object param is used in sidecar",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {},
+				"commit": {},
+			},
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:   "mysidecar",
+			Image:  "myimage",
+			Script: "echo $(params.gitrepo.non-exist-key)",
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "echo $(params.gitrepo.non-exist-key)"`,
+			Paths:   []string{"sidecars[0].script"},
+		},
+	}, {
+		name: "inexistent param variable",
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			Args:  []string{"--flag=$(params.inexistent)"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "--flag=$(params.inexistent)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "object used in a string field",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {},
+				"commit": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:       "do-the-clone",
+			Image:      "$(params.gitrepo)",
+			Args:       []string{"echo"},
+			WorkingDir: "/foo/bar/src/",
+		}},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo)"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "object star used in a string field",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {},
+				"commit": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:       "do-the-clone",
+			Image:      "$(params.gitrepo[*])",
+			Args:       []string{"echo"},
+			WorkingDir: "/foo/bar/src/",
+		}},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo[*])"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "object used in a field that can accept array type",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {},
+				"commit": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:       "do-the-clone",
+			Image:      "myimage",
+			Args:       []string{"$(params.gitrepo)"},
+			WorkingDir: "/foo/bar/src/",
+		}},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "object star used in a field that can accept array type",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {},
+				"commit": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:       "do-the-clone",
+			Image:      "some-git-image",
+			Args:       []string{"$(params.gitrepo[*])"},
+			WorkingDir: "/foo/bar/src/",
+		}},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "non-existent individual key of an object param is used in task step",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {},
+				"commit": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:       "do-the-clone",
+			Image:      "some-git-image",
+			Args:       []string{"$(params.gitrepo.non-exist-key)"},
+			WorkingDir: "/foo/bar/src/",
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.gitrepo.non-exist-key)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "Inexistent param variable in volumeMount with existing",
+		Params: []v1.ParamSpec{
+			{
+				Name:        "foo",
+				Description: "param",
+				Default:     v1.NewStructuredValues("default"),
+			},
+		},
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name: "$(params.inexistent)-foo",
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.inexistent)-foo"`,
+			Paths:   []string{"steps[0].volumeMount[0].name"},
+		},
+	}, {
+		name: "Inexistent param variable with existing",
+		Params: []v1.ParamSpec{{
+			Name:        "foo",
+			Description: "param",
+			Default:     v1.NewStructuredValues("default"),
+		}},
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			Args:  []string{"$(params.foo) && $(params.inexistent)"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.foo) && $(params.inexistent)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v1.ValidateUsageOfDeclaredParameters(context.Background(), tt.Steps, tt.Sidecars, tt.Params)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetArrayIndexParamRefs(t *testing.T) {
+	stepsReferences := []string{}
+	for i := 10; i <= 26; i++ {
+		stepsReferences = append(stepsReferences, fmt.Sprintf("$(params.array-params[%d])", i))
+	}
+	volumesReferences := []string{}
+	for i := 10; i <= 22; i++ {
+		volumesReferences = append(volumesReferences, fmt.Sprintf("$(params.array-params[%d])", i))
+	}
+
+	tcs := []struct {
+		name     string
+		taskspec *v1.TaskSpec
+		want     sets.String
+	}{
+		{
+			name: "steps reference",
+			taskspec: &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:    "array-params",
+					Default: v1.NewStructuredValues("bar", "foo"),
+				}},
+				Steps: []v1.Step{{
+					Name:    "$(params.array-params[10])",
+					Image:   "$(params.array-params[11])",
+					Command: []string{"$(params.array-params[12])"},
+					Args:    []string{"$(params.array-params[13])"},
+					Script:  "echo $(params.array-params[14])",
+					Env: []corev1.EnvVar{{
+						Value: "$(params.array-params[15])",
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								Key: "$(params.array-params[16])",
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "$(params.array-params[17])",
+								},
+							},
+							ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+								Key: "$(params.array-params[18])",
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "$(params.array-params[19])",
+								},
+							},
+						},
+					}},
+					EnvFrom: []corev1.EnvFromSource{{
+						Prefix: "$(params.array-params[20])",
+						ConfigMapRef: &corev1.ConfigMapEnvSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "$(params.array-params[21])",
+							},
+						},
+						SecretRef: &corev1.SecretEnvSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "$(params.array-params[22])",
+							},
+						},
+					}},
+					WorkingDir: "$(params.array-params[23])",
+					VolumeMounts: []corev1.VolumeMount{{
+						Name:      "$(params.array-params[24])",
+						MountPath: "$(params.array-params[25])",
+						SubPath:   "$(params.array-params[26])",
+					}},
+				}},
+				StepTemplate: &v1.StepTemplate{
+					Image: "$(params.array-params[27])",
+				},
+			},
+			want: sets.NewString("$(params.array-params[10])", "$(params.array-params[11])", "$(params.array-params[12])", "$(params.array-params[13])", "$(params.array-params[14])",
+				"$(params.array-params[15])", "$(params.array-params[16])", "$(params.array-params[17])", "$(params.array-params[18])", "$(params.array-params[19])", "$(params.array-params[20])",
+				"$(params.array-params[21])", "$(params.array-params[22])", "$(params.array-params[23])", "$(params.array-params[24])", "$(params.array-params[25])", "$(params.array-params[26])", "$(params.array-params[27])"),
+		}, {
+			name: "stepTemplate reference",
+			taskspec: &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:    "array-params",
+					Default: v1.NewStructuredValues("bar", "foo"),
+				}},
+				StepTemplate: &v1.StepTemplate{
+					Image: "$(params.array-params[3])",
+				},
+			},
+			want: sets.NewString("$(params.array-params[3])"),
+		}, {
+			name: "volumes references",
+			taskspec: &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:    "array-params",
+					Default: v1.NewStructuredValues("bar", "foo"),
+				}},
+				Volumes: []corev1.Volume{
+					{
+						Name: "$(params.array-params[10])",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "$(params.array-params[11])",
+								},
+								Items: []corev1.KeyToPath{
+									{
+										Key:  "$(params.array-params[12])",
+										Path: "$(params.array-params[13])",
+									},
+								},
+							},
+							Secret: &corev1.SecretVolumeSource{
+								SecretName: "$(params.array-params[14])",
+								Items: []corev1.KeyToPath{{
+									Key:  "$(params.array-params[15])",
+									Path: "$(params.array-params[16])",
+								}},
+							},
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "$(params.array-params[17])",
+							},
+							Projected: &corev1.ProjectedVolumeSource{
+								Sources: []corev1.VolumeProjection{{
+									ConfigMap: &corev1.ConfigMapProjection{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: "$(params.array-params[18])",
+										},
+									},
+									Secret: &corev1.SecretProjection{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: "$(params.array-params[19])",
+										},
+									},
+									ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+										Audience: "$(params.array-params[20])",
+									},
+								}},
+							},
+							CSI: &corev1.CSIVolumeSource{
+								NodePublishSecretRef: &corev1.LocalObjectReference{
+									Name: "$(params.array-params[21])",
+								},
+								VolumeAttributes: map[string]string{"key": "$(params.array-params[22])"},
+							},
+						},
+					},
+				},
+			},
+			want: sets.NewString("$(params.array-params[10])", "$(params.array-params[11])", "$(params.array-params[12])", "$(params.array-params[13])", "$(params.array-params[14])",
+				"$(params.array-params[15])", "$(params.array-params[16])", "$(params.array-params[17])", "$(params.array-params[18])", "$(params.array-params[19])", "$(params.array-params[20])",
+				"$(params.array-params[21])", "$(params.array-params[22])"),
+		}, {
+			name: "workspaces references",
+			taskspec: &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:    "array-params",
+					Default: v1.NewStructuredValues("bar", "foo"),
+				}},
+				Workspaces: []v1.WorkspaceDeclaration{{
+					MountPath: "$(params.array-params[3])",
+				}},
+			},
+			want: sets.NewString("$(params.array-params[3])"),
+		}, {
+			name: "sidecar references",
+			taskspec: &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:    "array-params",
+					Default: v1.NewStructuredValues("bar", "foo"),
+				}},
+				Sidecars: []v1.Sidecar{
+					{
+						Script: "$(params.array-params[3])",
+					},
+				},
+			},
+			want: sets.NewString("$(params.array-params[3])"),
+		}, {
+			name: "result value references",
+			taskspec: &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:    "array-params",
+					Default: v1.NewStructuredValues("bar", "foo"),
+				}},
+				Results: []v1.TaskResult{{
+					Name:  "string-result",
+					Value: v1.NewStructuredValues("$(params.array-params[4])"),
+				}, {
+					Name:  "array-result",
+					Type:  v1.ResultsTypeArray,
+					Value: v1.NewStructuredValues("$(params.array-params[5])", "$(params.array-params[6])"),
+				}, {
+					Name: "object-result",
+					Type: v1.ResultsTypeObject,
+					Properties: map[string]v1.PropertySpec{
+						"key": {},
+					},
+					Value: v1.NewObject(map[string]string{
+						"key": "$(params.array-params[7])",
+					}),
+				}},
+			},
+			want: sets.NewString("$(params.array-params[4])", "$(params.array-params[5])", "$(params.array-params[6])", "$(params.array-params[7])"),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.taskspec.GetIndexingReferencesToArrayParams()
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("GetIndexingReferencesToArrayParams diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecContextVariables(t *testing.T) {
+	tcs := []struct {
+		name     string
+		taskspec *v1.TaskSpec
+		want     sets.String
+	}{{
+		name: "step references",
+		taskspec: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image:  "bash",
+				Script: "echo $(context.task.name)",
+				Args:   []string{"$(context.taskRun.namespace)"},
+			}},
+		},
+		want: sets.NewString("context.task.name", "context.taskRun.namespace"),
+	}, {
+		name: "step template, workspace and sidecar references",
+		taskspec: &v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{
+				Env: []corev1.EnvVar{{
+					Name:  "TASKRUN_NAME",
+					Value: "$(context.taskRun.name)",
+				}},
+			},
+			Steps: []v1.Step{{
+				Image: "bash",
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "data",
+				MountPath: "/data/$(context.taskRun.uid)",
+			}},
+			Sidecars: []v1.Sidecar{{
+				Script: "echo $(context.task.retry-count)",
+			}},
+		},
+		want: sets.NewString("context.taskRun.name", "context.taskRun.uid", "context.task.retry-count"),
+	}, {
+		name: "no context references",
+		taskspec: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image: "bash",
+				Args:  []string{"$(params.foo)"},
+			}},
+		},
+		want: sets.NewString(),
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.taskspec.ContextVariables()
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("ContextVariables diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecMatchesContract(t *testing.T) {
+	contract := v1.ParamSpecs{{
+		Name: "url",
+		Type: v1.ParamTypeString,
+	}, {
+		Name: "flags",
+		Type: v1.ParamTypeArray,
+	}}
+	tcs := []struct {
+		name          string
+		params        v1.ParamSpecs
+		expectedError *apis.FieldError
+	}{{
+		name: "exact match",
+		params: v1.ParamSpecs{{
+			Name: "url",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "flags",
+			Type: v1.ParamTypeArray,
+		}},
+	}, {
+		name: "unset type matches string",
+		params: v1.ParamSpecs{{
+			Name: "url",
+		}, {
+			Name: "flags",
+			Type: v1.ParamTypeArray,
+		}},
+	}, {
+		name: "extra param",
+		params: v1.ParamSpecs{{
+			Name: "url",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "flags",
+			Type: v1.ParamTypeArray,
+		}, {
+			Name: "extra",
+			Type: v1.ParamTypeString,
+		}},
+		expectedError: &apis.FieldError{
+			Message: `param "extra" is not part of the expected contract`,
+			Paths:   []string{"params[extra]"},
+		},
+	}, {
+		name: "missing param",
+		params: v1.ParamSpecs{{
+			Name: "url",
+			Type: v1.ParamTypeString,
+		}},
+		expectedError: &apis.FieldError{
+			Message: `missing param "flags" required by the contract`,
+			Paths:   []string{"params"},
+		},
+	}, {
+		name: "type mismatch",
+		params: v1.ParamSpecs{{
+			Name: "url",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "flags",
+			Type: v1.ParamTypeString,
+		}},
+		expectedError: &apis.FieldError{
+			Message: `param "flags" has type string but the contract expects array`,
+			Paths:   []string{"params[flags]"},
+		},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: tc.params,
+				Steps:  validSteps,
+			}
+			err := ts.MatchesContract(contract)
+			if tc.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.MatchesContract() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tc.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.MatchesContract() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParamEnum_Success(t *testing.T) {
+	tcs := []struct {
+		name      string
+		params    v1.ParamSpecs
+		configMap map[string]string
+	}{{
+		name: "valid param enum - success",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{"v1", "v2"},
+		}, {
+			Name: "param2",
+			Type: v1.ParamTypeString,
+			Enum: []string{"v1", "v2"},
+		}},
+	}, {
+		name: "param enum value with variable reference and references enabled - success",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{"$(params.other)", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum":            "true",
+			"enable-param-enum-references": "true",
+		},
+	}, {
+		name: "valid empty param enum - success",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "param2",
+			Type: v1.ParamTypeString,
+		}},
+	}, {
+		name: "nil param enum - success",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: nil,
+		}},
+	}, {
+		name: "param enum values differing only by case in case-sensitive mode - success",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{"V1", "v1"},
+		}},
+	}, {
+		name: "param default matching enum case-insensitively - success",
+		params: []v1.ParamSpec{{
+			Name:                "param1",
+			Type:                v1.ParamTypeString,
+			Enum:                []string{"Debug", "Info"},
+			EnumCaseInsensitive: true,
+			Default: &v1.ParamValue{
+				Type:      v1.ParamTypeString,
+				StringVal: "debug",
+			},
+		}},
+	}}
+
+	for _, tc := range tcs {
+		cfg := tc.configMap
+		if cfg == nil {
+			cfg = map[string]string{"enable-param-enum": "true"}
+		}
+		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, cfg)
+
+		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
+		if err != nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		}
+	}
+}
+
+func TestParamEnum_Failure(t *testing.T) {
+	tcs := []struct {
+		name        string
+		params      v1.ParamSpecs
+		configMap   map[string]string
+		expectedErr error
+	}{{
+		name: "param default val not in enum list - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Default: &v1.ParamValue{
+				Type:      v1.ParamTypeString,
+				StringVal: "v4",
+			},
+			Enum: []string{"v1", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("param default value v4 not in the enum list: params[param1]"),
+	}, {
+		name: "param enum with array type - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeArray,
+			Enum: []string{"v1", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("enum can only be set with string type param: params[param1]"),
+	}, {
+		name: "param enum with object type - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeObject,
+			Enum: []string{"v1", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("enum can only be set with string type param: params[param1]"),
+	}, {
+		name: "param enum value with variable reference and references disabled - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{"$(params.other)", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("variable references not allowed in enum: params[param1]"),
+	}, {
+		name: "param default not matching enum case-sensitively - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{"Debug", "Info"},
+			Default: &v1.ParamValue{
+				Type:      v1.ParamTypeString,
+				StringVal: "debug",
+			},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("param default value debug not in the enum list: params[param1]"),
+	}, {
+		name: "param enum with values differing only by case - failure",
+		params: []v1.ParamSpec{{
+			Name:                "param1",
+			Type:                v1.ParamTypeString,
+			Enum:                []string{"V1", "v1"},
+			EnumCaseInsensitive: true,
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("parameter enum value v1 appears more than once: params[param1]"),
+	}, {
+		name: "param enum with duplicate values - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{"v1", "v1", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("parameter enum value v1 appears more than once: params[param1]"),
+	}, {
+		name: "param enum explicitly set to an empty list - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("enum must contain at least one value: params[param1]"),
+	}, {
+		name: "param enum with feature flag disabled - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{"v1", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "false",
+		},
+		expectedErr: errors.New("feature flag `enable-param-enum` should be set to true to use Enum: params[param1]"),
+	}}
+
+	for _, tc := range tcs {
+		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, tc.configMap)
+
+		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
+
+		if err == nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		} else if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+			t.Errorf("Rerturned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestParamElementEnum_Success(t *testing.T) {
+	tcs := []struct {
+		name   string
+		params v1.ParamSpecs
+	}{{
+		name: "array default elements all in element enum - success",
+		params: []v1.ParamSpec{{
+			Name:        "param1",
+			Type:        v1.ParamTypeArray,
+			ElementEnum: []string{"v1", "v2"},
+			Default: &v1.ParamValue{
+				Type:     v1.ParamTypeArray,
+				ArrayVal: []string{"v2", "v1"},
+			},
+		}},
+	}, {
+		name: "element enum without default - success",
+		params: []v1.ParamSpec{{
+			Name:        "param1",
+			Type:        v1.ParamTypeArray,
+			ElementEnum: []string{"v1", "v2"},
+		}},
+	}}
+
+	for _, tc := range tcs {
+		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+			"enable-param-enum": "true",
+		})
+		if err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params); err != nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		}
+	}
+}
+
+func TestParamElementEnum_Failure(t *testing.T) {
+	tcs := []struct {
+		name        string
+		params      v1.ParamSpecs
+		configMap   map[string]string
+		expectedErr error
+	}{{
+		name: "param default element not in element enum - failure",
+		params: []v1.ParamSpec{{
+			Name:        "param1",
+			Type:        v1.ParamTypeArray,
+			ElementEnum: []string{"v1", "v2"},
+			Default: &v1.ParamValue{
+				Type:     v1.ParamTypeArray,
+				ArrayVal: []string{"v1", "v4"},
+			},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("param default element v4 not in the enum list: params[param1]"),
+	}, {
+		name: "element enum with string type - failure",
+		params: []v1.ParamSpec{{
+			Name:        "param1",
+			Type:        v1.ParamTypeString,
+			ElementEnum: []string{"v1", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("elementEnum can only be set with array type param: params[param1]"),
+	}, {
+		name: "element enum with duplicate values - failure",
+		params: []v1.ParamSpec{{
+			Name:        "param1",
+			Type:        v1.ParamTypeArray,
+			ElementEnum: []string{"v1", "v1"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("parameter enum value v1 appears more than once: params[param1]"),
+	}, {
+		name: "element enum with feature flag disabled - failure",
+		params: []v1.ParamSpec{{
+			Name:        "param1",
+			Type:        v1.ParamTypeArray,
+			ElementEnum: []string{"v1", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "false",
+		},
+		expectedErr: errors.New("feature flag `enable-param-enum` should be set to true to use ElementEnum: params[param1]"),
+	}}
+
+	for _, tc := range tcs {
+		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, tc.configMap)
+
+		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
+
+		if err == nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		} else if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+			t.Errorf("Rerturned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestParamArrayIndexSyntax(t *testing.T) {
+	params := v1.ParamSpecs{{
+		Name: "arr",
+		Type: v1.ParamTypeArray,
+	}}
+	tcs := []struct {
+		name        string
+		steps       []v1.Step
+		expectedErr error
+	}{{
+		name: "valid integer index",
+		steps: []v1.Step{{
+			Image: "foo",
+			Args:  []string{"$(params.arr[3])"},
 		}},
-		expectedError: apis.FieldError{
-			Message: "script cannot be used with command",
-			Paths:   []string{"sidecars.script"},
-		},
+	}, {
+		name: "negative index",
+		steps: []v1.Step{{
+			Image: "foo",
+			Args:  []string{"$(params.arr[-1])"},
+		}},
+		expectedErr: errors.New(`invalid array index in "$(params.arr[-1])": steps[0].args[0]`),
+	}, {
+		name: "non-integer index",
+		steps: []v1.Step{{
+			Image: "foo",
+			Args:  []string{"$(params.arr[abc])"},
+		}},
+		expectedErr: errors.New(`invalid array index in "$(params.arr[abc])": steps[0].args[0]`),
 	}}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ts := &v1.TaskSpec{
-				Steps: []v1.Step{{
-					Name:  "does-not-matter",
-					Image: "does-not-matter",
-				}},
-				Sidecars: tt.sidecars,
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v1.ValidateParameterVariables(context.Background(), tc.steps, params)
+			if tc.expectedErr == nil {
+				if err != nil {
+					t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+				}
+				return
 			}
-			err := ts.Validate(context.Background())
 			if err == nil {
-				t.Fatalf("Expected an error, got nothing for %v", ts)
+				t.Fatalf("Expected an error from ValidateParameterVariables() but got nothing")
 			}
-			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
-				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+				t.Errorf("Returned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
 
-func TestTaskSpecValidateErrorSidecarName(t *testing.T) {
-	tests := []struct {
-		name          string
-		sidecars      []v1.Sidecar
-		expectedError apis.FieldError
+func TestObjectKeyIndexSyntax(t *testing.T) {
+	tcs := []struct {
+		name        string
+		params      v1.ParamSpecs
+		steps       []v1.Step
+		expectedErr error
 	}{{
-		name: "cannot use reserved sidecar name",
-		sidecars: []v1.Sidecar{{
-			Name:  "tekton-log-results",
-			Image: "my-image",
+		name: "index syntax on string property - failure",
+		params: v1.ParamSpecs{{
+			Name: "obj",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"key": {Type: v1.ParamTypeString},
+			},
+		}},
+		steps: []v1.Step{{
+			Image: "foo",
+			Args:  []string{"$(params.obj.key[0])"},
+		}},
+		expectedErr: errors.New(`invalid reference syntax in "$(params.obj.key[0])": steps[0].args[0]`),
+	}, {
+		name: "index syntax on array property - success",
+		params: v1.ParamSpecs{{
+			Name: "obj",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"key": {Type: v1.ParamTypeArray},
+			},
+		}},
+		steps: []v1.Step{{
+			Image: "foo",
+			Args:  []string{"$(params.obj.key[0])"},
 		}},
-		expectedError: apis.FieldError{
-			Message: fmt.Sprintf("Invalid: cannot use reserved sidecar name %v ", pipeline.ReservedResultsSidecarName),
-			Paths:   []string{"sidecars.name"},
-		},
 	}}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ts := &v1.TaskSpec{
-				Steps: []v1.Step{{
-					Name:  "does-not-matter",
-					Image: "does-not-matter",
-				}},
-				Sidecars: tt.sidecars,
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v1.ValidateParameterVariables(context.Background(), tc.steps, tc.params)
+			if tc.expectedErr == nil {
+				if err != nil {
+					t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+				}
+				return
 			}
-			err := ts.Validate(context.Background())
 			if err == nil {
-				t.Fatalf("Expected an error, got nothing for %v", ts)
+				t.Fatalf("Expected an error from ValidateParameterVariables() but got nothing")
 			}
-			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
-				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+				t.Errorf("Returned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
 
-func TestStepAndSidecarWorkspaces(t *testing.T) {
-	type fields struct {
-		Steps      []v1.Step
-		Sidecars   []v1.Sidecar
-		Workspaces []v1.WorkspaceDeclaration
-	}
-	tests := []struct {
+func TestParamExamples_Success(t *testing.T) {
+	tcs := []struct {
 		name   string
-		fields fields
+		params v1.ParamSpecs
 	}{{
-		name: "valid step workspace usage",
-		fields: fields{
-			Steps: []v1.Step{{
-				Image: "my-image",
-				Args:  []string{"arg"},
-				Workspaces: []v1.WorkspaceUsage{{
-					Name:      "foo-workspace",
-					MountPath: "/a/custom/mountpath",
-				}},
-			}},
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name:        "foo-workspace",
-				Description: "my great workspace",
-				MountPath:   "some/path",
-			}},
-		},
+		name: "valid param examples - success",
+		params: []v1.ParamSpec{{
+			Name:     "param1",
+			Type:     v1.ParamTypeString,
+			Examples: []string{"v1", "v2"},
+		}},
+	}, {
+		name: "valid param examples within enum - success",
+		params: []v1.ParamSpec{{
+			Name:     "param1",
+			Type:     v1.ParamTypeString,
+			Enum:     []string{"v1", "v2"},
+			Examples: []string{"v1"},
+		}},
+	}, {
+		name: "valid param examples matching pattern - success",
+		params: []v1.ParamSpec{{
+			Name:     "param1",
+			Type:     v1.ParamTypeString,
+			Pattern:  "^v[0-9]+$",
+			Examples: []string{"v1", "v2"},
+		}},
 	}}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ts := &v1.TaskSpec{
-				Steps:      tt.fields.Steps,
-				Sidecars:   tt.fields.Sidecars,
-				Workspaces: tt.fields.Workspaces,
-			}
-			ctx := cfgtesting.EnableAlphaAPIFields(context.Background())
-			ts.SetDefaults(ctx)
-			if err := ts.Validate(ctx); err != nil {
-				t.Errorf("TaskSpec.Validate() = %v", err)
+
+	for _, tc := range tcs {
+		cfg := map[string]string{"enable-param-enum": "true", "enable-param-pattern": "true"}
+		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, cfg)
+
+		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
+		if err != nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		}
+	}
+}
+
+func TestParamExamples_Failure(t *testing.T) {
+	tcs := []struct {
+		name        string
+		params      v1.ParamSpecs
+		expectedErr error
+	}{{
+		name: "param example not in enum list - failure",
+		params: []v1.ParamSpec{{
+			Name:     "param1",
+			Type:     v1.ParamTypeString,
+			Enum:     []string{"v1", "v2"},
+			Examples: []string{"v3"},
+		}},
+		expectedErr: errors.New("example value v3 not in the enum list: params[param1].examples"),
+	}, {
+		name: "param examples with array type - failure",
+		params: []v1.ParamSpec{{
+			Name:     "param1",
+			Type:     v1.ParamTypeArray,
+			Examples: []string{"v1"},
+		}},
+		expectedErr: errors.New("examples can only be set with string type param: params[param1].examples"),
+	}, {
+		name: "param example not matching pattern - failure",
+		params: []v1.ParamSpec{{
+			Name:     "param1",
+			Type:     v1.ParamTypeString,
+			Pattern:  "^v[0-9]+$",
+			Examples: []string{"version-3"},
+		}},
+		expectedErr: errors.New("example value version-3 does not match pattern ^v[0-9]+$: params[param1].examples"),
+	}}
+
+	for _, tc := range tcs {
+		cfg := map[string]string{"enable-param-enum": "true", "enable-param-pattern": "true"}
+		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, cfg)
+
+		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
+
+		if err == nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		} else if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+			t.Errorf("Rerturned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestParamNumber(t *testing.T) {
+	tcs := []struct {
+		name        string
+		params      v1.ParamSpecs
+		configMap   map[string]string
+		expectedErr error
+	}{{
+		name: "number param with numeric default - success",
+		params: []v1.ParamSpec{{
+			Name:    "replicas",
+			Type:    v1.ParamTypeNumber,
+			Default: v1.NewStructuredValues("3"),
+		}},
+	}, {
+		name: "number param with float default - success",
+		params: []v1.ParamSpec{{
+			Name:    "threshold",
+			Type:    v1.ParamTypeNumber,
+			Default: v1.NewStructuredValues("0.75"),
+		}},
+	}, {
+		name: "number param with non-numeric default - failure",
+		params: []v1.ParamSpec{{
+			Name:    "replicas",
+			Type:    v1.ParamTypeNumber,
+			Default: v1.NewStructuredValues("three"),
+		}},
+		expectedErr: errors.New(`invalid value: three: replicas.default`),
+	}, {
+		name: "number param with array default - failure",
+		params: []v1.ParamSpec{{
+			Name:    "replicas",
+			Type:    v1.ParamTypeNumber,
+			Default: v1.NewStructuredValues("1", "2"),
+		}},
+		expectedErr: errors.New(`"number" type does not match default value's type: "array": replicas.default.type, replicas.type`),
+	}, {
+		name: "number param without feature flag - failure",
+		params: []v1.ParamSpec{{
+			Name: "replicas",
+			Type: v1.ParamTypeNumber,
+		}},
+		configMap:   map[string]string{"enable-param-number": "false"},
+		expectedErr: errors.New("feature flag `enable-param-number` should be set to true to use number type param: replicas.type"),
+	}}
+
+	for _, tc := range tcs {
+		cfg := tc.configMap
+		if cfg == nil {
+			cfg = map[string]string{"enable-param-number": "true"}
+		}
+		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, cfg)
+
+		err := v1.ValidateParameterTypes(ctx, tc.params)
+		if tc.expectedErr == nil {
+			if err != nil {
+				t.Errorf("No error expected from ValidateParameterTypes() but got = %v", err)
 			}
-		})
+			continue
+		}
+		if err == nil {
+			t.Errorf("Expected an error from ValidateParameterTypes() but got nothing")
+		} else if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+			t.Errorf("Returned error from ValidateParameterTypes() does not match with the expected error: %s", diff.PrintWantGot(d))
+		}
 	}
 }
 
-func TestStepAndSidecarWorkspacesErrors(t *testing.T) {
-	type fields struct {
-		Steps    []v1.Step
-		Sidecars []v1.Sidecar
+func TestParamFormat_Success(t *testing.T) {
+	tcs := []struct {
+		name   string
+		params v1.ParamSpecs
+	}{{
+		name: "valid uri default - success",
+		params: []v1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1.ParamTypeString,
+			Format:  "uri",
+			Default: &v1.ParamValue{Type: v1.ParamTypeString, StringVal: "https://example.com/repo"},
+		}},
+	}, {
+		name: "valid date-time default - success",
+		params: []v1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1.ParamTypeString,
+			Format:  "date-time",
+			Default: &v1.ParamValue{Type: v1.ParamTypeString, StringVal: "2024-03-18T09:00:00Z"},
+		}},
+	}, {
+		name: "valid email enum - success",
+		params: []v1.ParamSpec{{
+			Name:   "param1",
+			Type:   v1.ParamTypeString,
+			Format: "email",
+			Enum:   []string{"dev@example.com", "ops@example.com"},
+		}},
+	}}
+
+	for _, tc := range tcs {
+		cfg := map[string]string{"enable-param-enum": "true", "enable-param-format": "true"}
+		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, cfg)
+
+		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
+		if err != nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		}
 	}
-	tests := []struct {
-		name          string
-		fields        fields
-		expectedError apis.FieldError
+}
+
+func TestParamFormat_Failure(t *testing.T) {
+	tcs := []struct {
+		name        string
+		params      v1.ParamSpecs
+		configMap   map[string]string
+		expectedErr error
 	}{{
-		name: "step workspace that refers to non-existent workspace declaration fails",
-		fields: fields{
-			Steps: []v1.Step{{
-				Image: "foo",
-				Workspaces: []v1.WorkspaceUsage{{
-					Name: "foo",
-				}},
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `undefined workspace "foo"`,
-			Paths:   []string{"steps[0].workspaces[0].name"},
-		},
+		name: "invalid uri default - failure",
+		params: []v1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1.ParamTypeString,
+			Format:  "uri",
+			Default: &v1.ParamValue{Type: v1.ParamTypeString, StringVal: "not a uri"},
+		}},
+		expectedErr: errors.New(`param default value not a uri does not match format "uri": params[param1]`),
+	}, {
+		name: "unknown format - failure",
+		params: []v1.ParamSpec{{
+			Name:   "param1",
+			Type:   v1.ParamTypeString,
+			Format: "hostname",
+		}},
+		expectedErr: errors.New(`unknown format "hostname": params[param1]`),
+	}, {
+		name: "format with array type - failure",
+		params: []v1.ParamSpec{{
+			Name:   "param1",
+			Type:   v1.ParamTypeArray,
+			Format: "uri",
+		}},
+		expectedErr: errors.New("format can only be set with string type param: params[param1]"),
+	}, {
+		name: "format without feature flag - failure",
+		params: []v1.ParamSpec{{
+			Name:   "param1",
+			Type:   v1.ParamTypeString,
+			Format: "uri",
+		}},
+		configMap:   map[string]string{"enable-param-format": "false"},
+		expectedErr: errors.New("feature flag `enable-param-format` should be set to true to use Format: params[param1]"),
+	}}
+
+	for _, tc := range tcs {
+		cfg := tc.configMap
+		if cfg == nil {
+			cfg = map[string]string{"enable-param-format": "true"}
+		}
+		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, cfg)
+
+		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
+
+		if err == nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		} else if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+			t.Errorf("Rerturned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestParamPattern_Success(t *testing.T) {
+	tcs := []struct {
+		name   string
+		params v1.ParamSpecs
+	}{{
+		name: "matching default - success",
+		params: []v1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1.ParamTypeString,
+			Pattern: "^v[0-9]+\\.[0-9]+$",
+			Default: &v1.ParamValue{Type: v1.ParamTypeString, StringVal: "v1.2"},
+		}},
 	}, {
-		name: "sidecar workspace that refers to non-existent workspace declaration fails",
-		fields: fields{
-			Steps: []v1.Step{{
-				Image: "foo",
-			}},
-			Sidecars: []v1.Sidecar{{
-				Image: "foo",
-				Workspaces: []v1.WorkspaceUsage{{
-					Name: "foo",
-				}},
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `undefined workspace "foo"`,
-			Paths:   []string{"sidecars[0].workspaces[0].name"},
-		},
+		name: "pattern without default - success",
+		params: []v1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1.ParamTypeString,
+			Pattern: "^[a-z-]+$",
+		}},
+	}, {
+		name: "enum values matching pattern - success",
+		params: []v1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1.ParamTypeString,
+			Pattern: "^v[0-9]+$",
+			Enum:    []string{"v1", "v2"},
+		}},
 	}}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ts := &v1.TaskSpec{
-				Steps:    tt.fields.Steps,
-				Sidecars: tt.fields.Sidecars,
-			}
 
-			ctx := cfgtesting.EnableAlphaAPIFields(context.Background())
-			ts.SetDefaults(ctx)
-			err := ts.Validate(ctx)
-			if err == nil {
-				t.Fatalf("Expected an error, got nothing for %v", ts)
-			}
+	for _, tc := range tcs {
+		cfg := map[string]string{"enable-param-pattern": "true", "enable-param-enum": "true"}
+		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, cfg)
 
-			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
-				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
-			}
-		})
+		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
+		if err != nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		}
 	}
 }
 
-func TestStepOnError(t *testing.T) {
-	tests := []struct {
-		name          string
-		params        []v1.ParamSpec
-		steps         []v1.Step
-		expectedError *apis.FieldError
+func TestParamPattern_Failure(t *testing.T) {
+	tcs := []struct {
+		name        string
+		params      v1.ParamSpecs
+		configMap   map[string]string
+		expectedErr error
 	}{{
-		name: "valid step - valid onError usage - set to continue",
-		steps: []v1.Step{{
-			OnError: v1.Continue,
-			Image:   "image",
-			Args:    []string{"arg"},
+		name: "default does not match pattern - failure",
+		params: []v1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1.ParamTypeString,
+			Pattern: "^v[0-9]+$",
+			Default: &v1.ParamValue{Type: v1.ParamTypeString, StringVal: "latest"},
 		}},
+		expectedErr: errors.New("param default value latest does not match pattern ^v[0-9]+$: params[param1]"),
 	}, {
-		name: "valid step - valid onError usage - set to stopAndFail",
-		steps: []v1.Step{{
-			OnError: v1.StopAndFail,
-			Image:   "image",
-			Args:    []string{"arg"},
+		name: "pattern with array type - failure",
+		params: []v1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1.ParamTypeArray,
+			Pattern: "^v[0-9]+$",
 		}},
+		expectedErr: errors.New("pattern can only be set with string type param: params[param1]"),
 	}, {
-		name: "valid step - valid onError usage - set to a task parameter",
+		name: "invalid regex - failure",
 		params: []v1.ParamSpec{{
-			Name:    "CONTINUE",
-			Default: &v1.ParamValue{Type: v1.ParamTypeString, StringVal: string(v1.Continue)},
+			Name:    "param1",
+			Type:    v1.ParamTypeString,
+			Pattern: "[unclosed",
 		}},
-		steps: []v1.Step{{
-			OnError: "$(params.CONTINUE)",
-			Image:   "image",
-			Args:    []string{"arg"},
+		expectedErr: errors.New("invalid pattern \"[unclosed\": error parsing regexp: missing closing ]: `[unclosed`: params[param1]"),
+	}, {
+		name: "pattern without feature flag - failure",
+		params: []v1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1.ParamTypeString,
+			Pattern: "^v[0-9]+$",
 		}},
+		configMap:   map[string]string{"enable-param-pattern": "false"},
+		expectedErr: errors.New("feature flag `enable-param-pattern` should be set to true to use Pattern: params[param1]"),
 	}, {
-		name: "invalid step - onError set to invalid value",
-		steps: []v1.Step{{
-			OnError: "onError",
-			Image:   "image",
-			Args:    []string{"arg"},
+		name: "enum value contradicts pattern - failure",
+		params: []v1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1.ParamTypeString,
+			Pattern: "^v[0-9]+$",
+			Enum:    []string{"v1", "latest"},
 		}},
-		expectedError: &apis.FieldError{
-			Message: `invalid value: "onError"`,
-			Paths:   []string{"steps[0].onError"},
-			Details: `Task step onError must be either "continue" or "stopAndFail"`,
-		},
+		expectedErr: errors.New("enum value latest does not match pattern ^v[0-9]+$: params[param1]"),
 	}}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ts := &v1.TaskSpec{
-				Params: tt.params,
-				Steps:  tt.steps,
-			}
-			ctx := context.Background()
-			ts.SetDefaults(ctx)
-			err := ts.Validate(ctx)
-			if tt.expectedError == nil && err != nil {
-				t.Errorf("No error expected from TaskSpec.Validate() but got = %v", err)
-			} else if tt.expectedError != nil {
-				if err == nil {
-					t.Errorf("Expected error from TaskSpec.Validate() = %v, but got none", tt.expectedError)
-				} else if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
-					t.Errorf("returned error from TaskSpec.Validate() does not match with the expected error: %s", diff.PrintWantGot(d))
-				}
-			}
-		})
-	}
-}
 
-// TestIncompatibleAPIVersions exercises validation of fields that
-// require a specific feature gate version in order to work.
-func TestIncompatibleAPIVersions(t *testing.T) {
-	versions := []string{"alpha", "beta", "stable"}
-	isStricterThen := func(first, second string) bool {
-		// assume values are in order alpha (less strict), beta, stable (strictest)
-		// return true if first is stricter then second
-		switch first {
-		case second, "alpha":
-			return false
-		case "stable":
-			return true
-		default:
-			// first is beta, true is second is alpha, false is second is stable
-			return second == "alpha"
+	for _, tc := range tcs {
+		cfg := tc.configMap
+		if cfg == nil {
+			cfg = map[string]string{"enable-param-pattern": "true", "enable-param-enum": "true"}
+		}
+		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, cfg)
+
+		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
+
+		if err == nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		} else if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+			t.Errorf("Rerturned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
 		}
 	}
+}
 
-	for _, tt := range []struct {
-		name            string
-		requiredVersion string
-		spec            v1.TaskSpec
-	}{
-		{
-			name:            "step workspace requires beta",
-			requiredVersion: "beta",
-			spec: v1.TaskSpec{
-				Workspaces: []v1.WorkspaceDeclaration{{
-					Name: "foo",
-				}},
-				Steps: []v1.Step{{
-					Image: "foo",
-					Workspaces: []v1.WorkspaceUsage{{
-						Name: "foo",
-					}},
-				}},
-			},
-		}, {
-			name:            "sidecar workspace requires beta",
-			requiredVersion: "beta",
-			spec: v1.TaskSpec{
-				Workspaces: []v1.WorkspaceDeclaration{{
-					Name: "foo",
-				}},
-				Steps: []v1.Step{{
-					Image: "foo",
-				}},
-				Sidecars: []v1.Sidecar{{
-					Image: "foo",
-					Workspaces: []v1.WorkspaceUsage{{
-						Name: "foo",
-					}},
-				}},
-			},
-		}, {
-			name:            "windows script support requires alpha",
-			requiredVersion: "alpha",
-			spec: v1.TaskSpec{
-				Steps: []v1.Step{{
-					Image: "my-image",
-					Script: `
-				#!win powershell -File
-				script-1`,
-				}},
-			},
-		}, {
-			name:            "stdout stream support requires alpha",
-			requiredVersion: "alpha",
-			spec: v1.TaskSpec{
-				Steps: []v1.Step{{
-					Image: "foo",
-					StdoutConfig: &v1.StepOutputConfig{
-						Path: "/tmp/stdout.txt",
-					},
-				}},
-			},
-		}, {
-			name:            "stderr stream support requires alpha",
-			requiredVersion: "alpha",
-			spec: v1.TaskSpec{
+func TestTaskSpecValidate_StepResults(t *testing.T) {
+	type fields struct {
+		Image   string
+		Args    []string
+		Script  string
+		Results []v1.StepResult
+	}
+	tests := []struct {
+		name   string
+		fields fields
+	}{{
+		name: "valid result",
+		fields: fields{
+			Image: "my-image",
+			Args:  []string{"arg"},
+			Results: []v1.StepResult{{
+				Name:        "MY-RESULT",
+				Description: "my great result",
+			}},
+		},
+	}, {
+		name: "valid result type string",
+		fields: fields{
+			Image:  "my-image",
+			Args:   []string{"arg"},
+			Script: "echo $(step.results.MY-RESULT.path)",
+			Results: []v1.StepResult{{
+				Name:        "MY-RESULT",
+				Type:        "string",
+				Description: "my great result",
+			}},
+		},
+	}, {
+		name: "valid result type array",
+		fields: fields{
+			Image: "my-image",
+			Args:  []string{"arg"},
+			Results: []v1.StepResult{{
+				Name:        "MY-RESULT",
+				Type:        v1.ResultsTypeArray,
+				Description: "my great result",
+			}},
+		},
+	}, {
+		name: "valid result type object",
+		fields: fields{
+			Image: "my-image",
+			Args:  []string{"arg"},
+			Results: []v1.StepResult{{
+				Name:        "MY-RESULT",
+				Type:        v1.ResultsTypeObject,
+				Description: "my great result",
+				Properties: map[string]v1.PropertySpec{
+					"url":    {Type: "string"},
+					"commit": {Type: "string"},
+				},
+			}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
 				Steps: []v1.Step{{
-					Image: "foo",
-					StderrConfig: &v1.StepOutputConfig{
-						Path: "/tmp/stderr.txt",
-					},
+					Image:   tt.fields.Image,
+					Args:    tt.fields.Args,
+					Script:  tt.fields.Script,
+					Results: tt.fields.Results,
 				}},
-			},
-		},
-	} {
-		for _, version := range versions {
-			testName := fmt.Sprintf("(using %s) %s", version, tt.name)
-			t.Run(testName, func(t *testing.T) {
-				ts := tt.spec
-				ctx := context.Background()
-				if version == "alpha" {
-					ctx = cfgtesting.EnableAlphaAPIFields(ctx)
-				}
-				if version == "beta" {
-					ctx = cfgtesting.EnableBetaAPIFields(ctx)
-				}
-				if version == "stable" {
-					ctx = cfgtesting.EnableStableAPIFields(ctx)
-				}
-				ts.SetDefaults(ctx)
-				err := ts.Validate(ctx)
-
-				// If the configured version is stricter than the required one, we expect an error
-				if isStricterThen(version, tt.requiredVersion) && err == nil {
-					t.Fatalf("no error received even though version required is %q while feature gate is %q", tt.requiredVersion, version)
-				}
-
-				// If the configured version is more permissive than the required one, we expect no error
-				if isStricterThen(tt.requiredVersion, version) && err != nil {
-					t.Fatalf("error received despite required version and feature gate matching %q: %v", version, err)
-				}
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableStepActions: true,
+				},
 			})
-		}
+			ts.SetDefaults(ctx)
+			if err := ts.Validate(ctx); err != nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			}
+		})
 	}
 }
 
-func TestTaskSpecValidateUsageOfDeclaredParams(t *testing.T) {
+func TestTaskSpecValidate_StepResults_Error(t *testing.T) {
+	type fields struct {
+		Image   string
+		Script  string
+		Results []v1.StepResult
+	}
 	tests := []struct {
-		name          string
-		Params        []v1.ParamSpec
-		Steps         []v1.Step
-		expectedError apis.FieldError
+		name              string
+		fields            fields
+		expectedError     apis.FieldError
+		isCreate          bool
+		isUpdate          bool
+		baselineTaskRun   *v1.TaskRun
+		enableStepActions bool
 	}{{
-		name: "inexistent param variable",
-		Steps: []v1.Step{{
-			Name:  "mystep",
-			Image: "myimage",
-			Args:  []string{"--flag=$(params.inexistent)"},
-		}},
+		name: "step result not allowed without enable step actions - create event",
+		fields: fields{
+			Image:   "my-image",
+			Results: []v1.StepResult{{Name: "a-result"}},
+		},
+		enableStepActions: false,
+		isCreate:          true,
 		expectedError: apis.FieldError{
-			Message: `non-existent variable in "--flag=$(params.inexistent)"`,
-			Paths:   []string{"steps[0].args[0]"},
+			Message: "feature flag enable-step-actions should be set to true in order to use Results in Steps.",
+			Paths:   []string{"steps[0]"},
 		},
 	}, {
-		name: "object used in a string field",
-		Params: []v1.ParamSpec{{
-			Name: "gitrepo",
-			Type: v1.ParamTypeObject,
-			Properties: map[string]v1.PropertySpec{
-				"url":    {},
-				"commit": {},
+		name: "step result not allowed without enable step actions - update and diverged event",
+		fields: fields{
+			Image:   "my-image",
+			Results: []v1.StepResult{{Name: "a-result"}},
+		},
+		enableStepActions: false,
+		isUpdate:          true,
+		baselineTaskRun: &v1.TaskRun{
+			Spec: v1.TaskRunSpec{
+				TaskSpec: &v1.TaskSpec{
+					Steps: []v1.Step{{
+						Image:   "my-image",
+						Results: []v1.StepResult{{Name: "b-result"}},
+					}},
+				},
 			},
-		}},
-		Steps: []v1.Step{{
-			Name:       "do-the-clone",
-			Image:      "$(params.gitrepo)",
-			Args:       []string{"echo"},
-			WorkingDir: "/foo/bar/src/",
-		}},
+		},
 		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo)"`,
-			Paths:   []string{"steps[0].image"},
+			Message: "feature flag enable-step-actions should be set to true in order to use Results in Steps.",
+			Paths:   []string{"steps[0]"},
 		},
 	}, {
-		name: "object star used in a string field",
-		Params: []v1.ParamSpec{{
-			Name: "gitrepo",
-			Type: v1.ParamTypeObject,
-			Properties: map[string]v1.PropertySpec{
-				"url":    {},
-				"commit": {},
+		name: "step result allowed without enable step actions - update but not diverged",
+		fields: fields{
+			Image:   "my-image",
+			Results: []v1.StepResult{{Name: "a-result"}},
+		},
+		enableStepActions: false,
+		isUpdate:          true,
+		baselineTaskRun: &v1.TaskRun{
+			Spec: v1.TaskRunSpec{
+				TaskSpec: &v1.TaskSpec{
+					Steps: []v1.Step{{
+						Image:   "my-image",
+						Results: []v1.StepResult{{Name: "a-result"}},
+					}},
+				},
 			},
-		}},
-		Steps: []v1.Step{{
-			Name:       "do-the-clone",
-			Image:      "$(params.gitrepo[*])",
-			Args:       []string{"echo"},
-			WorkingDir: "/foo/bar/src/",
-		}},
+		},
+		expectedError: apis.FieldError{},
+	}, {
+		name: "step result allowed without enable step actions - neither create nor update",
+		fields: fields{
+			Image:   "my-image",
+			Results: []v1.StepResult{{Name: "a-result"}},
+		},
+		enableStepActions: false,
+		expectedError:     apis.FieldError{},
+	}, {
+		name: "step script refers to nonexistent result",
+		fields: fields{
+			Image: "my-image",
+			Script: `
+			#!/usr/bin/env bash
+			date | tee $(results.non-exist.path)`,
+			Results: []v1.StepResult{{Name: "a-result"}},
+		},
 		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo[*])"`,
-			Paths:   []string{"steps[0].image"},
+			Message: `non-existent variable in "\n\t\t\t#!/usr/bin/env bash\n\t\t\tdate | tee $(results.non-exist.path)"`,
+			Paths:   []string{"steps[0].script"},
 		},
+		enableStepActions: true,
 	}, {
-		name: "object used in a field that can accept array type",
-		Params: []v1.ParamSpec{{
-			Name: "gitrepo",
-			Type: v1.ParamTypeObject,
-			Properties: map[string]v1.PropertySpec{
-				"url":    {},
-				"commit": {},
-			},
-		}},
-		Steps: []v1.Step{{
-			Name:       "do-the-clone",
-			Image:      "myimage",
-			Args:       []string{"$(params.gitrepo)"},
-			WorkingDir: "/foo/bar/src/",
-		}},
+		name: "step script refers to nonexistent stepresult",
+		fields: fields{
+			Image: "my-image",
+			Script: `
+			#!/usr/bin/env bash
+			date | tee $(step.results.non-exist.path)`,
+			Results: []v1.StepResult{{Name: "a-result"}},
+		},
 		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo)"`,
-			Paths:   []string{"steps[0].args[0]"},
+			Message: `non-existent variable in "\n\t\t\t#!/usr/bin/env bash\n\t\t\tdate | tee $(step.results.non-exist.path)"`,
+			Paths:   []string{"steps[0].script"},
 		},
+		enableStepActions: true,
 	}, {
-		name: "object star used in a field that can accept array type",
-		Params: []v1.ParamSpec{{
-			Name: "gitrepo",
-			Type: v1.ParamTypeObject,
-			Properties: map[string]v1.PropertySpec{
-				"url":    {},
-				"commit": {},
-			},
-		}},
-		Steps: []v1.Step{{
-			Name:       "do-the-clone",
-			Image:      "some-git-image",
-			Args:       []string{"$(params.gitrepo[*])"},
-			WorkingDir: "/foo/bar/src/",
-		}},
+		name: "step result declared more than once",
+		fields: fields{
+			Image:   "my-image",
+			Results: []v1.StepResult{{Name: "a-result"}, {Name: "a-result"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `result appears more than once`,
+			Paths:   []string{"steps.results[0][1].name"},
+		},
+		enableStepActions: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image:   tt.fields.Image,
+					Script:  tt.fields.Script,
+					Results: tt.fields.Results,
+				}},
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableStepActions: tt.enableStepActions,
+				},
+			})
+			if tt.isCreate {
+				ctx = apis.WithinCreate(ctx)
+			}
+			if tt.isUpdate {
+				ctx = apis.WithinUpdate(ctx, tt.baselineTaskRun)
+			}
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("StepActionSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateArrayStarInStringFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		step          v1.Step
+		expectedError apis.FieldError
+	}{{
+		name: "array star in workingDir",
+		step: v1.Step{
+			Image:      "my-image",
+			WorkingDir: "$(params.arr[*])",
+		},
 		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo[*])"`,
-			Paths:   []string{"steps[0].args[0]"},
+			Message: `variable type invalid in "$(params.arr[*])"`,
+			Paths:   []string{"steps[0].workingDir"},
 		},
 	}, {
-		name: "non-existent individual key of an object param is used in task step",
-		Params: []v1.ParamSpec{{
-			Name: "gitrepo",
-			Type: v1.ParamTypeObject,
-			Properties: map[string]v1.PropertySpec{
-				"url":    {},
-				"commit": {},
-			},
-		}},
-		Steps: []v1.Step{{
-			Name:       "do-the-clone",
-			Image:      "some-git-image",
-			Args:       []string{"$(params.gitrepo.non-exist-key)"},
-			WorkingDir: "/foo/bar/src/",
-		}},
+		name: "array star in env value",
+		step: v1.Step{
+			Image: "my-image",
+			Env: []corev1.EnvVar{{
+				Name:  "FOO",
+				Value: "$(params.arr[*])",
+			}},
+		},
 		expectedError: apis.FieldError{
-			Message: `non-existent variable in "$(params.gitrepo.non-exist-key)"`,
-			Paths:   []string{"steps[0].args[0]"},
+			Message: `variable type invalid in "$(params.arr[*])"`,
+			Paths:   []string{"steps[0].env[FOO]"},
 		},
 	}, {
-		name: "Inexistent param variable in volumeMount with existing",
-		Params: []v1.ParamSpec{
-			{
-				Name:        "foo",
-				Description: "param",
-				Default:     v1.NewStructuredValues("default"),
-			},
-		},
-		Steps: []v1.Step{{
-			Name:  "mystep",
-			Image: "myimage",
+		name: "array star in volumeMount name",
+		step: v1.Step{
+			Image: "my-image",
 			VolumeMounts: []corev1.VolumeMount{{
-				Name: "$(params.inexistent)-foo",
+				Name:      "$(params.arr[*])",
+				MountPath: "/mount",
 			}},
-		}},
+		},
 		expectedError: apis.FieldError{
-			Message: `non-existent variable in "$(params.inexistent)-foo"`,
+			Message: `variable type invalid in "$(params.arr[*])"`,
 			Paths:   []string{"steps[0].volumeMount[0].name"},
 		},
 	}, {
-		name: "Inexistent param variable with existing",
-		Params: []v1.ParamSpec{{
-			Name:        "foo",
-			Description: "param",
-			Default:     v1.NewStructuredValues("default"),
-		}},
-		Steps: []v1.Step{{
-			Name:  "mystep",
-			Image: "myimage",
-			Args:  []string{"$(params.foo) && $(params.inexistent)"},
-		}},
+		name: "array star in volumeMount mountPath",
+		step: v1.Step{
+			Image: "my-image",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "my-mount",
+				MountPath: "$(params.arr[*])",
+			}},
+		},
 		expectedError: apis.FieldError{
-			Message: `non-existent variable in "$(params.foo) && $(params.inexistent)"`,
-			Paths:   []string{"steps[0].args[0]"},
+			Message: `variable type invalid in "$(params.arr[*])"`,
+			Paths:   []string{"steps[0].volumeMount[0].mountPath"},
+		},
+	}, {
+		name: "array star in volumeMount subPath",
+		step: v1.Step{
+			Image: "my-image",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "my-mount",
+				MountPath: "/mount",
+				SubPath:   "$(params.arr[*])",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.arr[*])"`,
+			Paths:   []string{"steps[0].volumeMount[0].subPath"},
+		},
+	}, {
+		name: "array star in envFrom prefix",
+		step: v1.Step{
+			Image: "my-image",
+			EnvFrom: []corev1.EnvFromSource{{
+				Prefix: "$(params.arr[*])",
+				ConfigMapRef: &corev1.ConfigMapEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "config"},
+				},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.arr[*])"`,
+			Paths:   []string{"steps[0].envFrom[0].prefix"},
 		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := v1.ValidateUsageOfDeclaredParameters(context.Background(), tt.Steps, tt.Params)
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name: "arr",
+					Type: v1.ParamTypeArray,
+				}},
+				Steps: []v1.Step{tt.step},
+			}
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
 			if err == nil {
-				t.Fatalf("Expected an error, got nothing")
+				t.Fatalf("Expected an error, got nothing for %v", ts)
 			}
 			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
 				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
@@ -2611,518 +6341,583 @@ func TestTaskSpecValidateUsageOfDeclaredParams(t *testing.T) {
 	}
 }
 
-func TestGetArrayIndexParamRefs(t *testing.T) {
-	stepsReferences := []string{}
-	for i := 10; i <= 26; i++ {
-		stepsReferences = append(stepsReferences, fmt.Sprintf("$(params.array-params[%d])", i))
+func TestTaskSpecValidateWorkspaceMountPathsWithConfiguredPrefix(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Image: "my-image",
+		}},
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name: "ws",
+		}, {
+			Name:      "other",
+			MountPath: "/tekton/workspaces/ws",
+		}},
 	}
-	volumesReferences := []string{}
-	for i := 10; i <= 22; i++ {
-		volumesReferences = append(volumesReferences, fmt.Sprintf("$(params.array-params[%d])", i))
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{
+			DefaultWorkspacePrefix: "/tekton/workspaces",
+		},
+		FeatureFlags: &config.FeatureFlags{},
+	})
+	ts.SetDefaults(ctx)
+	err := ts.Validate(ctx)
+	if err == nil {
+		t.Fatalf("Expected an error, got nothing for %v", ts)
 	}
+	expectedError := apis.FieldError{
+		Message: `workspace mount path "/tekton/workspaces/ws" must be unique`,
+		Paths:   []string{"workspaces[1].mountpath"},
+	}
+	if d := cmp.Diff(expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+		t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+	}
+}
 
-	tcs := []struct {
-		name     string
-		taskspec *v1.TaskSpec
-		want     sets.String
-	}{
-		{
-			name: "steps reference",
-			taskspec: &v1.TaskSpec{
-				Params: []v1.ParamSpec{{
-					Name:    "array-params",
-					Default: v1.NewStructuredValues("bar", "foo"),
-				}},
+func TestStepTaskResultCollision(t *testing.T) {
+	tests := []struct {
+		name          string
+		configMap     map[string]string
+		expectedError *apis.FieldError
+	}{{
+		name:      "collision is an error by default",
+		configMap: map[string]string{"enable-step-actions": "true"},
+		expectedError: &apis.FieldError{
+			Message: `step result name "data" collides with a task result name`,
+			Paths:   []string{"steps[0].results[0].name"},
+		},
+	}, {
+		name: "collision allowed when overlap flag is set",
+		configMap: map[string]string{
+			"enable-step-actions":            "true",
+			"allow-step-task-result-overlap": "true",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
 				Steps: []v1.Step{{
-					Name:    "$(params.array-params[10])",
-					Image:   "$(params.array-params[11])",
-					Command: []string{"$(params.array-params[12])"},
-					Args:    []string{"$(params.array-params[13])"},
-					Script:  "echo $(params.array-params[14])",
-					Env: []corev1.EnvVar{{
-						Value: "$(params.array-params[15])",
-						ValueFrom: &corev1.EnvVarSource{
-							SecretKeyRef: &corev1.SecretKeySelector{
-								Key: "$(params.array-params[16])",
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: "$(params.array-params[17])",
-								},
-							},
-							ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
-								Key: "$(params.array-params[18])",
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: "$(params.array-params[19])",
-								},
-							},
-						},
-					}},
-					EnvFrom: []corev1.EnvFromSource{{
-						Prefix: "$(params.array-params[20])",
-						ConfigMapRef: &corev1.ConfigMapEnvSource{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: "$(params.array-params[21])",
-							},
-						},
-						SecretRef: &corev1.SecretEnvSource{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: "$(params.array-params[22])",
-							},
-						},
-					}},
-					WorkingDir: "$(params.array-params[23])",
-					VolumeMounts: []corev1.VolumeMount{{
-						Name:      "$(params.array-params[24])",
-						MountPath: "$(params.array-params[25])",
-						SubPath:   "$(params.array-params[26])",
-					}},
-				}},
-				StepTemplate: &v1.StepTemplate{
-					Image: "$(params.array-params[27])",
-				},
-			},
-			want: sets.NewString("$(params.array-params[10])", "$(params.array-params[11])", "$(params.array-params[12])", "$(params.array-params[13])", "$(params.array-params[14])",
-				"$(params.array-params[15])", "$(params.array-params[16])", "$(params.array-params[17])", "$(params.array-params[18])", "$(params.array-params[19])", "$(params.array-params[20])",
-				"$(params.array-params[21])", "$(params.array-params[22])", "$(params.array-params[23])", "$(params.array-params[24])", "$(params.array-params[25])", "$(params.array-params[26])", "$(params.array-params[27])"),
-		}, {
-			name: "stepTemplate reference",
-			taskspec: &v1.TaskSpec{
-				Params: []v1.ParamSpec{{
-					Name:    "array-params",
-					Default: v1.NewStructuredValues("bar", "foo"),
-				}},
-				StepTemplate: &v1.StepTemplate{
-					Image: "$(params.array-params[3])",
-				},
-			},
-			want: sets.NewString("$(params.array-params[3])"),
-		}, {
-			name: "volumes references",
-			taskspec: &v1.TaskSpec{
-				Params: []v1.ParamSpec{{
-					Name:    "array-params",
-					Default: v1.NewStructuredValues("bar", "foo"),
-				}},
-				Volumes: []corev1.Volume{
-					{
-						Name: "$(params.array-params[10])",
-						VolumeSource: corev1.VolumeSource{
-							ConfigMap: &corev1.ConfigMapVolumeSource{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: "$(params.array-params[11])",
-								},
-								Items: []corev1.KeyToPath{
-									{
-										Key:  "$(params.array-params[12])",
-										Path: "$(params.array-params[13])",
-									},
-								},
-							},
-							Secret: &corev1.SecretVolumeSource{
-								SecretName: "$(params.array-params[14])",
-								Items: []corev1.KeyToPath{{
-									Key:  "$(params.array-params[15])",
-									Path: "$(params.array-params[16])",
-								}},
-							},
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-								ClaimName: "$(params.array-params[17])",
-							},
-							Projected: &corev1.ProjectedVolumeSource{
-								Sources: []corev1.VolumeProjection{{
-									ConfigMap: &corev1.ConfigMapProjection{
-										LocalObjectReference: corev1.LocalObjectReference{
-											Name: "$(params.array-params[18])",
-										},
-									},
-									Secret: &corev1.SecretProjection{
-										LocalObjectReference: corev1.LocalObjectReference{
-											Name: "$(params.array-params[19])",
-										},
-									},
-									ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
-										Audience: "$(params.array-params[20])",
-									},
-								}},
-							},
-							CSI: &corev1.CSIVolumeSource{
-								NodePublishSecretRef: &corev1.LocalObjectReference{
-									Name: "$(params.array-params[21])",
-								},
-								VolumeAttributes: map[string]string{"key": "$(params.array-params[22])"},
-							},
-						},
-					},
-				},
-			},
-			want: sets.NewString("$(params.array-params[10])", "$(params.array-params[11])", "$(params.array-params[12])", "$(params.array-params[13])", "$(params.array-params[14])",
-				"$(params.array-params[15])", "$(params.array-params[16])", "$(params.array-params[17])", "$(params.array-params[18])", "$(params.array-params[19])", "$(params.array-params[20])",
-				"$(params.array-params[21])", "$(params.array-params[22])"),
-		}, {
-			name: "workspaces references",
-			taskspec: &v1.TaskSpec{
-				Params: []v1.ParamSpec{{
-					Name:    "array-params",
-					Default: v1.NewStructuredValues("bar", "foo"),
-				}},
-				Workspaces: []v1.WorkspaceDeclaration{{
-					MountPath: "$(params.array-params[3])",
+					Image:   "my-image",
+					Script:  "echo hello",
+					Results: []v1.StepResult{{Name: "data"}},
 				}},
+				Results: []v1.TaskResult{{Name: "data"}},
+			}
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, tt.configMap)
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateSecurityContextConflicts(t *testing.T) {
+	tests := []struct {
+		name          string
+		stepTemplate  *v1.StepTemplate
+		steps         []v1.Step
+		flagEnabled   bool
+		expectedError *apis.FieldError
+	}{{
+		name: "step runs as non-root user",
+		steps: []v1.Step{{
+			Image: "my-image",
+			SecurityContext: &corev1.SecurityContext{
+				RunAsNonRoot: pointer.Bool(true),
+				RunAsUser:    pointer.Int64(1000),
 			},
-			want: sets.NewString("$(params.array-params[3])"),
-		}, {
-			name: "sidecar references",
-			taskspec: &v1.TaskSpec{
-				Params: []v1.ParamSpec{{
-					Name:    "array-params",
-					Default: v1.NewStructuredValues("bar", "foo"),
-				}},
-				Sidecars: []v1.Sidecar{
-					{
-						Script: "$(params.array-params[3])",
-					},
-				},
+		}},
+		flagEnabled: true,
+	}, {
+		name: "step sets runAsNonRoot with runAsUser 0",
+		steps: []v1.Step{{
+			Image: "my-image",
+			SecurityContext: &corev1.SecurityContext{
+				RunAsNonRoot: pointer.Bool(true),
+				RunAsUser:    pointer.Int64(0),
 			},
-			want: sets.NewString("$(params.array-params[3])"),
+		}},
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: "runAsNonRoot conflicts with runAsUser 0",
+			Paths:   []string{"steps[0].securityContext"},
 		},
-	}
-	for _, tc := range tcs {
-		t.Run(tc.name, func(t *testing.T) {
-			got := tc.taskspec.GetIndexingReferencesToArrayParams()
-			if d := cmp.Diff(tc.want, got); d != "" {
-				t.Errorf("GetIndexingReferencesToArrayParams diff %s", diff.PrintWantGot(d))
+	}, {
+		name: "template runAsNonRoot conflicts with step runAsUser 0",
+		stepTemplate: &v1.StepTemplate{
+			SecurityContext: &corev1.SecurityContext{
+				RunAsNonRoot: pointer.Bool(true),
+			},
+		},
+		steps: []v1.Step{{
+			Image: "my-image",
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser: pointer.Int64(0),
+			},
+		}},
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: "runAsNonRoot conflicts with runAsUser 0",
+			Paths:   []string{"steps[0].securityContext"},
+		},
+	}, {
+		name: "step overrides template runAsUser 0",
+		stepTemplate: &v1.StepTemplate{
+			SecurityContext: &corev1.SecurityContext{
+				RunAsNonRoot: pointer.Bool(true),
+				RunAsUser:    pointer.Int64(0),
+			},
+		},
+		steps: []v1.Step{{
+			Image: "my-image",
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser: pointer.Int64(1000),
+			},
+		}},
+		flagEnabled: true,
+	}, {
+		name: "conflict allowed with the flag disabled",
+		steps: []v1.Step{{
+			Image: "my-image",
+			SecurityContext: &corev1.SecurityContext{
+				RunAsNonRoot: pointer.Bool(true),
+				RunAsUser:    pointer.Int64(0),
+			},
+		}},
+		flagEnabled: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				StepTemplate: tt.stepTemplate,
+				Steps:        tt.steps,
+			}
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+				"validate-security-context-conflicts": strconv.FormatBool(tt.flagEnabled),
+			})
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("TaskSpec.Validate() did not return an error for %v", tt.steps)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
 
-func TestParamEnum_Success(t *testing.T) {
-	tcs := []struct {
-		name      string
-		params    v1.ParamSpecs
-		configMap map[string]string
+func TestTaskSpecValidatePropertyDefaults(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        []v1.ParamSpec
+		expectedError *apis.FieldError
 	}{{
-		name: "valid param enum - success",
+		name: "property defaults for declared properties",
 		params: []v1.ParamSpec{{
-			Name: "param1",
-			Type: v1.ParamTypeString,
-			Enum: []string{"v1", "v2"},
-		}, {
-			Name: "param2",
-			Type: v1.ParamTypeString,
-			Enum: []string{"v1", "v2"},
+			Name:             "myobj",
+			Type:             v1.ParamTypeObject,
+			Properties:       map[string]v1.PropertySpec{"key1": {Type: "string"}, "key2": {Type: "string"}},
+			PropertyDefaults: map[string]string{"key2": "fallback"},
+			Default: &v1.ParamValue{
+				Type:      v1.ParamTypeObject,
+				ObjectVal: map[string]string{"key1": "foo"},
+			},
 		}},
 	}, {
-		name: "valid empty param enum - success",
+		name: "property default for an undeclared key",
 		params: []v1.ParamSpec{{
-			Name: "param1",
+			Name:             "myobj",
+			Type:             v1.ParamTypeObject,
+			Properties:       map[string]v1.PropertySpec{"key1": {Type: "string"}},
+			PropertyDefaults: map[string]string{"key3": "fallback"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `unknown property default "key3"`,
+			Paths:   []string{"params.myobj.propertyDefaults"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: tt.params,
+				Steps: []v1.Step{{
+					Image: "my-image",
+				}},
+			}
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("TaskSpec.Validate() did not return an error for %v", tt.params)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateAllReportsEveryError(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{
+			Name: "myparam",
 			Type: v1.ParamTypeString,
 		}, {
-			Name: "param2",
+			Name: "myparam",
 			Type: v1.ParamTypeString,
 		}},
-	}}
-
-	for _, tc := range tcs {
-		cfg := map[string]string{"enable-param-enum": "true"}
-		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, cfg)
-
-		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
-		if err != nil {
-			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "my-image",
+		}, {
+			Name:  "mystep",
+			Image: "my-image",
+		}},
+	}
+	ctx := context.Background()
+	ts.SetDefaults(ctx)
+	err := ts.ValidateAll(ctx)
+	if err == nil {
+		t.Fatalf("TaskSpec.ValidateAll() did not return an error for %v", ts)
+	}
+	for _, want := range []string{"params[myparam]", "steps[1].name"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("TaskSpec.ValidateAll() = %v, expected it to mention %q", err, want)
 		}
 	}
 }
 
-func TestParamEnum_Failure(t *testing.T) {
-	tcs := []struct {
-		name        string
-		params      v1.ParamSpecs
-		configMap   map[string]string
-		expectedErr error
+func TestTaskSpecValidateParamResultCollisions(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        []v1.ParamSpec
+		results       []v1.TaskResult
+		flagEnabled   bool
+		expectedError *apis.FieldError
 	}{{
-		name: "param default val not in enum list - failure",
+		name: "distinct param and result names",
 		params: []v1.ParamSpec{{
-			Name: "param1",
+			Name: "input",
 			Type: v1.ParamTypeString,
-			Default: &v1.ParamValue{
-				Type:      v1.ParamTypeString,
-				StringVal: "v4",
-			},
-			Enum: []string{"v1", "v2"},
-		}},
-		configMap: map[string]string{
-			"enable-param-enum": "true",
-		},
-		expectedErr: errors.New("param default value v4 not in the enum list: params[param1]"),
-	}, {
-		name: "param enum with array type - failure",
-		params: []v1.ParamSpec{{
-			Name: "param1",
-			Type: v1.ParamTypeArray,
-			Enum: []string{"v1", "v2"},
 		}},
-		configMap: map[string]string{
-			"enable-param-enum": "true",
-		},
-		expectedErr: errors.New("enum can only be set with string type param: params[param1]"),
-	}, {
-		name: "param enum with object type - failure",
-		params: []v1.ParamSpec{{
-			Name: "param1",
-			Type: v1.ParamTypeObject,
-			Enum: []string{"v1", "v2"},
+		results: []v1.TaskResult{{
+			Name: "output",
 		}},
-		configMap: map[string]string{
-			"enable-param-enum": "true",
-		},
-		expectedErr: errors.New("enum can only be set with string type param: params[param1]"),
+		flagEnabled: true,
 	}, {
-		name: "param enum with duplicate values - failure",
+		name: "param and result share a name",
 		params: []v1.ParamSpec{{
-			Name: "param1",
+			Name: "artifact",
 			Type: v1.ParamTypeString,
-			Enum: []string{"v1", "v1", "v2"},
 		}},
-		configMap: map[string]string{
-			"enable-param-enum": "true",
+		results: []v1.TaskResult{{
+			Name: "artifact",
+		}},
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: `name "artifact" used for both a param and a result`,
+			Paths:   []string{"results[0].name"},
 		},
-		expectedErr: errors.New("parameter enum value v1 appears more than once: params[param1]"),
 	}, {
-		name: "param enum with feature flag disabled - failure",
+		name: "collision allowed with the flag disabled",
 		params: []v1.ParamSpec{{
-			Name: "param1",
+			Name: "artifact",
 			Type: v1.ParamTypeString,
-			Enum: []string{"v1", "v2"},
 		}},
-		configMap: map[string]string{
-			"enable-param-enum": "false",
-		},
-		expectedErr: errors.New("feature flag `enable-param-enum` should be set to true to use Enum: params[param1]"),
+		results: []v1.TaskResult{{
+			Name: "artifact",
+		}},
+		flagEnabled: false,
 	}}
-
-	for _, tc := range tcs {
-		ctx := cfgtesting.SetFeatureFlags(context.Background(), t, tc.configMap)
-
-		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
-
-		if err == nil {
-			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
-		} else if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
-			t.Errorf("Rerturned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
-		}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: tt.params,
+				Steps: []v1.Step{{
+					Image: "my-image",
+				}},
+				Results: tt.results,
+			}
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+				"forbid-param-result-collisions": strconv.FormatBool(tt.flagEnabled),
+			})
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("TaskSpec.Validate() did not return an error for %v", tt.results)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
 	}
 }
 
-func TestTaskSpecValidate_StepResults(t *testing.T) {
-	type fields struct {
-		Image   string
-		Args    []string
-		Script  string
-		Results []v1.StepResult
+func TestStepAndSidecarWorkspacesOptionalWarning(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Workspaces: []v1.WorkspaceUsage{{
+				Name: "foo-workspace",
+			}},
+		}},
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name:     "foo-workspace",
+			Optional: true,
+		}},
+	}
+	ctx := cfgtesting.EnableAlphaAPIFields(context.Background())
+	ts.SetDefaults(ctx)
+	err := ts.Validate(ctx)
+	if err == nil {
+		t.Fatalf("Expected a warning, got nothing for %v", ts)
+	}
+	if errLevel := err.Filter(apis.ErrorLevel); errLevel != nil {
+		t.Errorf("TaskSpec.Validate() = %v, expected no error-level findings", errLevel)
+	}
+	expectedWarning := apis.FieldError{
+		Message: `workspace "foo-workspace" is optional and may be absent at runtime`,
+		Paths:   []string{"steps[0].workspaces[0].name"},
 	}
+	warning := err.Filter(apis.WarningLevel)
+	if warning == nil {
+		t.Fatalf("Expected a warning, got nothing for %v", ts)
+	}
+	if d := cmp.Diff(expectedWarning.Error(), warning.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+		t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskSpecValidateRequireExplicitStepNames(t *testing.T) {
 	tests := []struct {
-		name   string
-		fields fields
+		name          string
+		steps         []v1.Step
+		flagEnabled   bool
+		expectedError *apis.FieldError
 	}{{
-		name: "valid result",
-		fields: fields{
+		name: "named steps",
+		steps: []v1.Step{{
+			Name:  "mystep",
 			Image: "my-image",
-			Args:  []string{"arg"},
-			Results: []v1.StepResult{{
-				Name:        "MY-RESULT",
-				Description: "my great result",
-			}},
-		},
+		}},
+		flagEnabled: true,
 	}, {
-		name: "valid result type string",
-		fields: fields{
-			Image:  "my-image",
-			Args:   []string{"arg"},
-			Script: "echo $(step.results.MY-RESULT.path)",
-			Results: []v1.StepResult{{
-				Name:        "MY-RESULT",
-				Type:        "string",
-				Description: "my great result",
-			}},
+		name: "anonymous step",
+		steps: []v1.Step{{
+			Image: "my-image",
+		}},
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: "step name is required",
+			Paths:   []string{"steps[0].name"},
 		},
 	}, {
-		name: "valid result type array",
-		fields: fields{
+		name: "anonymous second step",
+		steps: []v1.Step{{
+			Name:  "mystep",
 			Image: "my-image",
-			Args:  []string{"arg"},
-			Results: []v1.StepResult{{
-				Name:        "MY-RESULT",
-				Type:        v1.ResultsTypeArray,
-				Description: "my great result",
-			}},
+		}, {
+			Image: "my-image",
+		}},
+		flagEnabled: true,
+		expectedError: &apis.FieldError{
+			Message: "step name is required",
+			Paths:   []string{"steps[1].name"},
 		},
 	}, {
-		name: "valid result type object",
-		fields: fields{
+		name: "anonymous step allowed with the flag disabled",
+		steps: []v1.Step{{
 			Image: "my-image",
-			Args:  []string{"arg"},
-			Results: []v1.StepResult{{
-				Name:        "MY-RESULT",
-				Type:        v1.ResultsTypeObject,
-				Description: "my great result",
-				Properties: map[string]v1.PropertySpec{
-					"url":    {Type: "string"},
-					"commit": {Type: "string"},
-				},
-			}},
-		},
+		}},
+		flagEnabled: false,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &v1.TaskSpec{
-				Steps: []v1.Step{{
-					Image:   tt.fields.Image,
-					Args:    tt.fields.Args,
-					Script:  tt.fields.Script,
-					Results: tt.fields.Results,
-				}},
+				Steps: tt.steps,
 			}
-			ctx := config.ToContext(context.Background(), &config.Config{
-				FeatureFlags: &config.FeatureFlags{
-					EnableStepActions: true,
-				},
+			ctx := cfgtesting.SetFeatureFlags(context.Background(), t, map[string]string{
+				"require-explicit-step-names": strconv.FormatBool(tt.flagEnabled),
 			})
 			ts.SetDefaults(ctx)
-			if err := ts.Validate(ctx); err != nil {
-				t.Errorf("TaskSpec.Validate() = %v", err)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("TaskSpec.Validate() did not return an error for %v", tt.steps)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
 
-func TestTaskSpecValidate_StepResults_Error(t *testing.T) {
-	type fields struct {
-		Image   string
-		Script  string
-		Results []v1.StepResult
-	}
+func TestTaskSpecValidateSidecarNames(t *testing.T) {
 	tests := []struct {
-		name              string
-		fields            fields
-		expectedError     apis.FieldError
-		isCreate          bool
-		isUpdate          bool
-		baselineTaskRun   *v1.TaskRun
-		enableStepActions bool
+		name          string
+		steps         []v1.Step
+		sidecars      []v1.Sidecar
+		expectedError *apis.FieldError
 	}{{
-		name: "step result not allowed without enable step actions - create event",
-		fields: fields{
-			Image:   "my-image",
-			Results: []v1.StepResult{{Name: "a-result"}},
-		},
-		enableStepActions: false,
-		isCreate:          true,
-		expectedError: apis.FieldError{
-			Message: "feature flag enable-step-actions should be set to true in order to use Results in Steps.",
-			Paths:   []string{"steps[0]"},
-		},
+		name: "unique sidecar names",
+		steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "my-image",
+		}},
+		sidecars: []v1.Sidecar{{
+			Name:  "proxy",
+			Image: "my-image",
+		}, {
+			Name:  "db",
+			Image: "my-image",
+		}},
 	}, {
-		name: "step result not allowed without enable step actions - update and diverged event",
-		fields: fields{
-			Image:   "my-image",
-			Results: []v1.StepResult{{Name: "a-result"}},
-		},
-		enableStepActions: false,
-		isUpdate:          true,
-		baselineTaskRun: &v1.TaskRun{
-			Spec: v1.TaskRunSpec{
-				TaskSpec: &v1.TaskSpec{
-					Steps: []v1.Step{{
-						Image:   "my-image",
-						Results: []v1.StepResult{{Name: "b-result"}},
-					}},
-				},
-			},
-		},
-		expectedError: apis.FieldError{
-			Message: "feature flag enable-step-actions should be set to true in order to use Results in Steps.",
-			Paths:   []string{"steps[0]"},
+		name: "duplicate sidecar name",
+		steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "my-image",
+		}},
+		sidecars: []v1.Sidecar{{
+			Name:  "proxy",
+			Image: "my-image",
+		}, {
+			Name:  "proxy",
+			Image: "my-image",
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: proxy`,
+			Paths:   []string{"sidecars[1].name"},
 		},
 	}, {
-		name: "step result allowed without enable step actions - update but not diverged",
-		fields: fields{
-			Image:   "my-image",
-			Results: []v1.StepResult{{Name: "a-result"}},
-		},
-		enableStepActions: false,
-		isUpdate:          true,
-		baselineTaskRun: &v1.TaskRun{
-			Spec: v1.TaskRunSpec{
-				TaskSpec: &v1.TaskSpec{
-					Steps: []v1.Step{{
-						Image:   "my-image",
-						Results: []v1.StepResult{{Name: "a-result"}},
-					}},
-				},
-			},
+		name: "sidecar name collides with step name",
+		steps: []v1.Step{{
+			Name:  "x",
+			Image: "my-image",
+		}},
+		sidecars: []v1.Sidecar{{
+			Name:  "x",
+			Image: "my-image",
+		}},
+		expectedError: &apis.FieldError{
+			Message: `sidecar name "x" conflicts with step name`,
+			Paths:   []string{"sidecars[0].name"},
 		},
-		expectedError: apis.FieldError{},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:    tt.steps,
+				Sidecars: tt.sidecars,
+			}
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("TaskSpec.Validate() did not return an error for %v", tt.sidecars)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateWarningsShebang(t *testing.T) {
+	tests := []struct {
+		name            string
+		steps           []v1.Step
+		expectedWarning *apis.FieldError
+	}{{
+		name: "script with shebang",
+		steps: []v1.Step{{
+			Image:  "my-image",
+			Script: "#!/usr/bin/env bash\necho hello",
+		}},
 	}, {
-		name: "step result allowed without enable step actions - neither create nor update",
-		fields: fields{
+		name: "step with command only",
+		steps: []v1.Step{{
 			Image:   "my-image",
-			Results: []v1.StepResult{{Name: "a-result"}},
-		},
-		enableStepActions: false,
-		expectedError:     apis.FieldError{},
+			Command: []string{"echo", "hello"},
+		}},
 	}, {
-		name: "step script refers to nonexistent result",
-		fields: fields{
-			Image: "my-image",
-			Script: `
-			#!/usr/bin/env bash
-			date | tee $(results.non-exist.path)`,
-			Results: []v1.StepResult{{Name: "a-result"}},
-		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "\n\t\t\t#!/usr/bin/env bash\n\t\t\tdate | tee $(results.non-exist.path)"`,
+		name: "script without shebang",
+		steps: []v1.Step{{
+			Image:  "my-image",
+			Script: "echo hello",
+		}},
+		expectedWarning: &apis.FieldError{
+			Message: "script does not begin with a shebang line, a default interpreter will be used",
 			Paths:   []string{"steps[0].script"},
 		},
-		enableStepActions: true,
 	}, {
-		name: "step script refers to nonexistent stepresult",
-		fields: fields{
-			Image: "my-image",
-			Script: `
-			#!/usr/bin/env bash
-			date | tee $(step.results.non-exist.path)`,
-			Results: []v1.StepResult{{Name: "a-result"}},
-		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "\n\t\t\t#!/usr/bin/env bash\n\t\t\tdate | tee $(step.results.non-exist.path)"`,
-			Paths:   []string{"steps[0].script"},
-		},
-		enableStepActions: true,
+		name: "leading whitespace before shebang",
+		steps: []v1.Step{{
+			Image:  "my-image",
+			Script: "\n\n#!/bin/sh\necho hello",
+		}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &v1.TaskSpec{
-				Steps: []v1.Step{{
-					Image:   tt.fields.Image,
-					Script:  tt.fields.Script,
-					Results: tt.fields.Results,
-				}},
+				Steps: tt.steps,
 			}
-			ctx := config.ToContext(context.Background(), &config.Config{
-				FeatureFlags: &config.FeatureFlags{
-					EnableStepActions: tt.enableStepActions,
-				},
-			})
-			if tt.isCreate {
-				ctx = apis.WithinCreate(ctx)
+			ctx := context.Background()
+			ts.SetDefaults(ctx)
+			warnings := ts.ValidateWarnings(ctx)
+			if tt.expectedWarning == nil {
+				if warnings != nil {
+					t.Errorf("TaskSpec.ValidateWarnings() = %v", warnings)
+				}
+				return
 			}
-			if tt.isUpdate {
-				ctx = apis.WithinUpdate(ctx, tt.baselineTaskRun)
+			if warnings == nil {
+				t.Fatalf("TaskSpec.ValidateWarnings() returned nothing for %v", tt.steps)
 			}
-			ts.SetDefaults(ctx)
-			err := ts.Validate(ctx)
-			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
-				t.Errorf("StepActionSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			if errLevel := warnings.Filter(apis.ErrorLevel); errLevel != nil {
+				t.Errorf("TaskSpec.ValidateWarnings() = %v, expected warning severity only", errLevel)
+			}
+			if d := cmp.Diff(tt.expectedWarning.Error(), warnings.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.ValidateWarnings() diff %s", diff.PrintWantGot(d))
 			}
 		})
 	}
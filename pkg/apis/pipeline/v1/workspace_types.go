@@ -17,10 +17,14 @@ limitations under the License.
 package v1
 
 import (
+	"context"
 	"path/filepath"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/substitution"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // WorkspaceDeclaration is a declaration of a volume that a Task requires.
@@ -50,6 +54,110 @@ func (w *WorkspaceDeclaration) GetMountPath() string {
 	return filepath.Join(pipeline.WorkspaceDir, w.Name)
 }
 
+// GetMountPathWithContext returns the mountPath for w, using the
+// default-workspace-prefix configured in ctx when no explicit MountPath is
+// set. An empty prefix falls back to the usual /workspace default.
+func (w *WorkspaceDeclaration) GetMountPathWithContext(ctx context.Context) string {
+	if w.MountPath != "" {
+		return w.MountPath
+	}
+	prefix := ""
+	if cfg := config.FromContextOrDefaults(ctx); cfg.Defaults != nil {
+		prefix = cfg.Defaults.DefaultWorkspacePrefix
+	}
+	if prefix == "" {
+		prefix = pipeline.WorkspaceDir
+	}
+	return filepath.Join(prefix, w.Name)
+}
+
+// WorkspaceMountPaths returns the resolved mount path for each declared
+// workspace, keyed by workspace name: the explicit MountPath when one is set,
+// otherwise the configured default-workspace-prefix (or /workspace) joined
+// with the workspace name. It makes no assumptions about the spec being valid,
+// so it is safe to call on a spec that fails validation.
+func (ts *TaskSpec) WorkspaceMountPaths(ctx context.Context) map[string]string {
+	paths := make(map[string]string, len(ts.Workspaces))
+	for _, w := range ts.Workspaces {
+		paths[w.Name] = filepath.Clean(w.GetMountPathWithContext(ctx))
+	}
+	return paths
+}
+
+// WorkspacesUsedByStep returns the names of the workspaces used by the Step at
+// stepIndex, combining the workspaces the step explicitly declares usage of with
+// the workspaces it implicitly references via variables such as
+// $(workspaces.name.path). An out-of-range stepIndex returns the empty set.
+func (ts *TaskSpec) WorkspacesUsedByStep(stepIndex int) sets.String {
+	used := sets.NewString()
+	if stepIndex < 0 || stepIndex >= len(ts.Steps) {
+		return used
+	}
+	step := ts.Steps[stepIndex]
+	for _, w := range step.Workspaces {
+		used.Insert(w.Name)
+	}
+
+	locationsToCheck := []string{step.Script, step.WorkingDir}
+	locationsToCheck = append(locationsToCheck, step.Command...)
+	locationsToCheck = append(locationsToCheck, step.Args...)
+	for _, e := range step.Env {
+		locationsToCheck = append(locationsToCheck, e.Value)
+	}
+	for _, p := range step.Params {
+		locationsToCheck = append(locationsToCheck, p.Value.StringVal)
+		locationsToCheck = append(locationsToCheck, p.Value.ArrayVal...)
+		for k := range p.Value.ObjectVal {
+			locationsToCheck = append(locationsToCheck, p.Value.ObjectVal[k])
+		}
+	}
+	for _, loc := range locationsToCheck {
+		workspacesUsed, _, _ := substitution.ExtractVariablesFromString(loc, "workspaces")
+		used.Insert(workspacesUsed...)
+	}
+	return used
+}
+
+// StepsUsingWorkspace returns the indices of the steps and sidecars that use
+// the named workspace, combining explicit WorkspaceUsage declarations with
+// implicit references via variables such as $(workspaces.name.path).
+func (ts *TaskSpec) StepsUsingWorkspace(name string) (steps []int, sidecars []int) {
+	for idx := range ts.Steps {
+		if ts.WorkspacesUsedByStep(idx).Has(name) {
+			steps = append(steps, idx)
+		}
+	}
+	for idx, sc := range ts.Sidecars {
+		if sidecarUsesWorkspace(sc, name) {
+			sidecars = append(sidecars, idx)
+		}
+	}
+	return steps, sidecars
+}
+
+// sidecarUsesWorkspace reports whether the sidecar declares usage of the named
+// workspace or references it via variables.
+func sidecarUsesWorkspace(sc Sidecar, name string) bool {
+	for _, w := range sc.Workspaces {
+		if w.Name == name {
+			return true
+		}
+	}
+	locationsToCheck := []string{sc.Script, sc.WorkingDir}
+	locationsToCheck = append(locationsToCheck, sc.Command...)
+	locationsToCheck = append(locationsToCheck, sc.Args...)
+	for _, e := range sc.Env {
+		locationsToCheck = append(locationsToCheck, e.Value)
+	}
+	for _, loc := range locationsToCheck {
+		workspacesUsed, _, _ := substitution.ExtractVariablesFromString(loc, "workspaces")
+		if sets.NewString(workspacesUsed...).Has(name) {
+			return true
+		}
+	}
+	return false
+}
+
 // WorkspaceBinding maps a Task's declared workspace to a Volume.
 type WorkspaceBinding struct {
 	// Name is the name of the workspace populated by the volume.
@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestWorkspacesUsedByStep(t *testing.T) {
+	tests := []struct {
+		name      string
+		ts        *v1.TaskSpec
+		stepIndex int
+		want      sets.String
+	}{{
+		name: "explicit usage only",
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image: "my-image",
+				Workspaces: []v1.WorkspaceUsage{{
+					Name: "explicit-ws",
+				}},
+			}},
+		},
+		stepIndex: 0,
+		want:      sets.NewString("explicit-ws"),
+	}, {
+		name: "implicit usage only",
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image:  "my-image",
+				Script: "ls $(workspaces.script-ws.path)",
+				Args:   []string{"$(workspaces.args-ws.path)"},
+				Env: []corev1.EnvVar{{
+					Name:  "WS_PATH",
+					Value: "$(workspaces.env-ws.path)",
+				}},
+			}},
+		},
+		stepIndex: 0,
+		want:      sets.NewString("script-ws", "args-ws", "env-ws"),
+	}, {
+		name: "explicit and implicit usage",
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image:   "my-image",
+				Command: []string{"cat", "$(workspaces.implicit-ws.path)/file"},
+				Workspaces: []v1.WorkspaceUsage{{
+					Name: "explicit-ws",
+				}},
+			}},
+		},
+		stepIndex: 0,
+		want:      sets.NewString("explicit-ws", "implicit-ws"),
+	}, {
+		name: "only the requested step is considered",
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image:  "my-image",
+				Script: "ls $(workspaces.first-ws.path)",
+			}, {
+				Image:  "my-image",
+				Script: "ls $(workspaces.second-ws.path)",
+			}},
+		},
+		stepIndex: 1,
+		want:      sets.NewString("second-ws"),
+	}, {
+		name: "out of range index",
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image: "my-image",
+			}},
+		},
+		stepIndex: 1,
+		want:      sets.NewString(),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.ts.WorkspacesUsedByStep(tt.stepIndex)
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("WorkspacesUsedByStep() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepsUsingWorkspace(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Image: "my-image",
+			Workspaces: []v1.WorkspaceUsage{{
+				Name: "data",
+			}},
+		}, {
+			Image:  "my-image",
+			Script: "ls $(workspaces.other.path)",
+		}, {
+			Image: "my-image",
+			Args:  []string{"$(workspaces.data.path)/file"},
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:  "explicit-sidecar",
+			Image: "my-image",
+			Workspaces: []v1.WorkspaceUsage{{
+				Name: "data",
+			}},
+		}, {
+			Name:    "unrelated-sidecar",
+			Image:   "my-image",
+			Command: []string{"sleep", "infinity"},
+		}, {
+			Name:  "implicit-sidecar",
+			Image: "my-image",
+			Env: []corev1.EnvVar{{
+				Name:  "WS_PATH",
+				Value: "$(workspaces.data.path)",
+			}},
+		}},
+	}
+	tests := []struct {
+		name         string
+		workspace    string
+		wantSteps    []int
+		wantSidecars []int
+	}{{
+		name:         "explicit and implicit usages",
+		workspace:    "data",
+		wantSteps:    []int{0, 2},
+		wantSidecars: []int{0, 2},
+	}, {
+		name:      "implicit step usage only",
+		workspace: "other",
+		wantSteps: []int{1},
+	}, {
+		name:      "unused workspace",
+		workspace: "missing",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSteps, gotSidecars := ts.StepsUsingWorkspace(tt.workspace)
+			if d := cmp.Diff(tt.wantSteps, gotSteps); d != "" {
+				t.Errorf("StepsUsingWorkspace() steps %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tt.wantSidecars, gotSidecars); d != "" {
+				t.Errorf("StepsUsingWorkspace() sidecars %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestWorkspaceMountPaths(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name: "defaulted",
+		}, {
+			Name:      "explicit",
+			MountPath: "/a/custom/mountpath",
+		}},
+	}
+
+	want := map[string]string{
+		"defaulted": "/workspace/defaulted",
+		"explicit":  "/a/custom/mountpath",
+	}
+	got := ts.WorkspaceMountPaths(context.Background())
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("WorkspaceMountPaths() %s", diff.PrintWantGot(d))
+	}
+
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{
+			DefaultWorkspacePrefix: "/tekton/workspaces",
+		},
+		FeatureFlags: &config.FeatureFlags{},
+	})
+	wantPrefixed := map[string]string{
+		"defaulted": "/tekton/workspaces/defaulted",
+		"explicit":  "/a/custom/mountpath",
+	}
+	gotPrefixed := ts.WorkspaceMountPaths(ctx)
+	if d := cmp.Diff(wantPrefixed, gotPrefixed); d != "" {
+		t.Errorf("WorkspaceMountPaths() with configured prefix %s", diff.PrintWantGot(d))
+	}
+}
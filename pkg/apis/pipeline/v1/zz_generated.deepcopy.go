@@ -302,6 +302,13 @@ func (in *ParamSpec) DeepCopyInto(out *ParamSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.PropertyDefaults != nil {
+		in, out := &in.PropertyDefaults, &out.PropertyDefaults
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Default != nil {
 		in, out := &in.Default, &out.Default
 		*out = new(ParamValue)
@@ -312,6 +319,31 @@ func (in *ParamSpec) DeepCopyInto(out *ParamSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ElementEnum != nil {
+		in, out := &in.ElementEnum, &out.ElementEnum
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Examples != nil {
+		in, out := &in.Examples, &out.Examples
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Requires != nil {
+		in, out := &in.Requires, &out.Requires
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Order != nil {
+		in, out := &in.Order, &out.Order
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -1641,6 +1673,11 @@ func (in *TaskResult) DeepCopyInto(out *TaskResult) {
 		*out = new(ParamValue)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
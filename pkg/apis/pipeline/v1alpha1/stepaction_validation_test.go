@@ -876,6 +876,7 @@ func TestStepActionSpecValidateError(t *testing.T) {
 		},
 		expectedError: apis.FieldError{
 			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
+			Details: `array params must be used as a complete, isolated value like "$(params.baz[*])"`,
 			Paths:   []string{"args[0]"},
 		},
 	}, {
@@ -894,6 +895,7 @@ func TestStepActionSpecValidateError(t *testing.T) {
 		},
 		expectedError: apis.FieldError{
 			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
+			Details: `array params must be used as a complete, isolated value like "$(params.baz[*])"`,
 			Paths:   []string{"args[0]"},
 		},
 	}, {
@@ -912,6 +914,7 @@ func TestStepActionSpecValidateError(t *testing.T) {
 		},
 		expectedError: apis.FieldError{
 			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
+			Details: `array params must be used as a complete, isolated value like "$(params.baz[*])"`,
 			Paths:   []string{"args[0]"},
 		},
 	}, {
@@ -930,6 +933,7 @@ func TestStepActionSpecValidateError(t *testing.T) {
 		},
 		expectedError: apis.FieldError{
 			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
+			Details: `array params must be used as a complete, isolated value like "$(params.baz[*])"`,
 			Paths:   []string{"args[0]"},
 		},
 	}, {
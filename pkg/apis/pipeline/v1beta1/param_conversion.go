@@ -31,6 +31,17 @@ func (p ParamSpec) convertTo(ctx context.Context, sink *v1.ParamSpec) {
 	}
 	sink.Description = p.Description
 	sink.Enum = p.Enum
+	sink.EnumCaseInsensitive = p.EnumCaseInsensitive
+	sink.ElementEnum = p.ElementEnum
+	sink.Examples = p.Examples
+	sink.Format = p.Format
+	sink.Pattern = p.Pattern
+	sink.Requires = p.Requires
+	sink.Conflicts = p.Conflicts
+	sink.DeprecationMessage = p.DeprecationMessage
+	sink.Group = p.Group
+	sink.Order = p.Order
+	sink.PropertyDefaults = p.PropertyDefaults
 	var properties map[string]v1.PropertySpec
 	if p.Properties != nil {
 		properties = make(map[string]v1.PropertySpec)
@@ -56,6 +67,17 @@ func (p *ParamSpec) convertFrom(ctx context.Context, source v1.ParamSpec) {
 	}
 	p.Description = source.Description
 	p.Enum = source.Enum
+	p.EnumCaseInsensitive = source.EnumCaseInsensitive
+	p.ElementEnum = source.ElementEnum
+	p.Examples = source.Examples
+	p.Format = source.Format
+	p.Pattern = source.Pattern
+	p.Requires = source.Requires
+	p.Conflicts = source.Conflicts
+	p.DeprecationMessage = source.DeprecationMessage
+	p.Group = source.Group
+	p.Order = source.Order
+	p.PropertyDefaults = source.PropertyDefaults
 	var properties map[string]PropertySpec
 	if source.Properties != nil {
 		properties = make(map[string]PropertySpec)
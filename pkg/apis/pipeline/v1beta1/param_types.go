@@ -50,6 +50,11 @@ type ParamSpec struct {
 	// Properties is the JSON Schema properties to support key-value pairs parameter.
 	// +optional
 	Properties map[string]PropertySpec `json:"properties,omitempty"`
+	// PropertyDefaults declares per-key default values for an object param.
+	// Keys omitted from Default fall back to the value declared here; the
+	// fallback values are merged into Default when defaults are applied.
+	// +optional
+	PropertyDefaults map[string]string `json:"propertyDefaults,omitempty"`
 	// Default is the value a parameter takes if no input value is supplied. If
 	// default is set, a Task may be executed without a supplied value for the
 	// parameter.
@@ -59,6 +64,53 @@ type ParamSpec struct {
 	// If Enum is not set, no input validation is performed for the param.
 	// +optional
 	Enum []string `json:"enum,omitempty"`
+	// EnumCaseInsensitive makes Enum values match case-insensitively, both when
+	// checking the default value against the enum and when detecting duplicate
+	// enum values. Defaults to false, keeping enum matching case-sensitive.
+	// +optional
+	EnumCaseInsensitive bool `json:"enumCaseInsensitive,omitempty"`
+	// ElementEnum declares a set of allowed values for the elements of an
+	// array param. Every element of the array default must appear in the list.
+	// If ElementEnum is not set, no element validation is performed.
+	// +optional
+	ElementEnum []string `json:"elementEnum,omitempty"`
+	// Examples are example input values for the param that may be used to
+	// populate documentation such as a catalog entry. Examples are validated
+	// against the param's declared type and constraints.
+	// +optional
+	Examples []string `json:"examples,omitempty"`
+	// Format is a semantic format hint for the param value, similar to the JSON
+	// Schema "format" keyword. The supported formats are "uri", "date-time" and
+	// "email". Default and Enum values are validated against the format.
+	// +optional
+	Format string `json:"format,omitempty"`
+	// Pattern is an RE2 regular expression that the param value must match.
+	// Pattern may only be set on string type params and the default value (if
+	// present) is validated against it.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+	// Requires lists params that must also be provided when a value for this
+	// param is supplied.
+	// +optional
+	Requires []string `json:"requires,omitempty"`
+	// Conflicts lists params that must not be provided when a value for this
+	// param is supplied.
+	// +optional
+	Conflicts []string `json:"conflicts,omitempty"`
+	// DeprecationMessage marks the param as deprecated. When set and the param
+	// is referenced anywhere in the spec, validation emits a warning carrying
+	// this message so consumers can migrate before the param is removed.
+	// +optional
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+	// Group names the group the param belongs to, so that UIs generated from
+	// the spec can bucket related params together. Grouping has no effect on
+	// runtime behavior.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Order is the position of the param within its group, used by generated
+	// UIs to order params. Orders must be unique within a group.
+	// +optional
+	Order *int `json:"order,omitempty"`
 }
 
 // ParamSpecs is a list of ParamSpec
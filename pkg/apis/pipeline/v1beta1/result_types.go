@@ -36,6 +36,12 @@ type TaskResult struct {
 	// Value the expression used to retrieve the value of the result from an underlying Step.
 	// +optional
 	Value *ResultValue `json:"value,omitempty"`
+
+	// MaxSize is the expected maximum size of the result value in bytes. It
+	// documents the author's intent and must not exceed the cluster-wide
+	// max-result-size cap.
+	// +optional
+	MaxSize *int `json:"maxSize,omitempty"`
 }
 
 // TaskRunResult used to describe the results of a task
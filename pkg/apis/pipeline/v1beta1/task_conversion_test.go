@@ -224,9 +224,22 @@ spec:
     description: my first param
     properties:
       foo: {type: string}
+    propertyDefaults:
+      foo: bar
     default:
       type: string
       stringVal: bar
+    enum: ["bar", "baz"]
+    enumCaseInsensitive: true
+    elementEnum: ["bar", "baz"]
+    examples: ["bar"]
+    format: uri
+    pattern: "^ba[rz]$"
+    requires: ["param-2"]
+    conflicts: ["param-3"]
+    deprecationMessage: use param-2 instead
+    group: group-1
+    order: 1
   workspaces:
   - name: workspace
     description: a workspace
@@ -239,6 +252,7 @@ spec:
     properties:
       property: {type: string}
     description: description
+    maxSize: 1024
 `
 
 	taskWithDeprecatedFieldsV1beta1YAML := `
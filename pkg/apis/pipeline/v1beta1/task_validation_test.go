@@ -1144,6 +1144,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 		},
 		expectedError: apis.FieldError{
 			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
+			Details: `array params must be used as a complete, isolated value like "$(params.baz[*])"`,
 			Paths:   []string{"steps[0].args[0]"},
 		},
 	}, {
@@ -1166,6 +1167,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 		},
 		expectedError: apis.FieldError{
 			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
+			Details: `array params must be used as a complete, isolated value like "$(params.baz[*])"`,
 			Paths:   []string{"steps[0].args[0]"},
 		},
 	}, {
@@ -1188,6 +1190,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 		},
 		expectedError: apis.FieldError{
 			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
+			Details: `array params must be used as a complete, isolated value like "$(params.baz[*])"`,
 			Paths:   []string{"steps[0].args[0]"},
 		},
 	}, {
@@ -1210,6 +1213,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 		},
 		expectedError: apis.FieldError{
 			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
+			Details: `array params must be used as a complete, isolated value like "$(params.baz[*])"`,
 			Paths:   []string{"steps[0].args[0]"},
 		},
 	}, {
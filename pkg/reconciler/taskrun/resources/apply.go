@@ -292,7 +292,7 @@ func ApplyWorkspaces(ctx context.Context, spec *v1.TaskSpec, declarations []v1.W
 			stringReplacements[prefix+"path"] = ""
 		} else {
 			stringReplacements[prefix+"bound"] = "true"
-			spec = applyWorkspaceMountPath(prefix+"path", spec, declaration)
+			spec = applyWorkspaceMountPath(ctx, prefix+"path", spec, declaration)
 		}
 	}
 
@@ -321,10 +321,10 @@ func ApplyParametersToWorkspaceBindings(ts *v1.TaskSpec, tr *v1.TaskRun) *v1.Tas
 // it in the fields of the TaskSpec. A new updated TaskSpec is returned. Steps or Sidecars in the TaskSpec
 // that override the mountPath will receive that mountPath in place of the variable's value. Other Steps and
 // Sidecars will see either the workspace's declared mountPath or the default of /workspaces/<name>.
-func applyWorkspaceMountPath(variable string, spec *v1.TaskSpec, declaration v1.WorkspaceDeclaration) *v1.TaskSpec {
+func applyWorkspaceMountPath(ctx context.Context, variable string, spec *v1.TaskSpec, declaration v1.WorkspaceDeclaration) *v1.TaskSpec {
 	stringReplacements := map[string]string{variable: ""}
 	emptyArrayReplacements := map[string][]string{}
-	defaultMountPath := declaration.GetMountPath()
+	defaultMountPath := declaration.GetMountPathWithContext(ctx)
 	// Replace instances of the workspace path variable that are overridden per-Step
 	for i := range spec.Steps {
 		step := &spec.Steps[i]
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/workspace"
@@ -1205,6 +1206,33 @@ func TestApplyWorkspaces(t *testing.T) {
 	}
 }
 
+func TestApplyWorkspaces_ConfiguredPrefix(t *testing.T) {
+	spec := &v1.TaskSpec{Steps: []v1.Step{{
+		Script: `ls $(workspaces.ws.path)`,
+	}}}
+	decls := []v1.WorkspaceDeclaration{{
+		Name: "ws",
+	}}
+	binds := []v1.WorkspaceBinding{{
+		Name:     "ws",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}}
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{
+			DefaultWorkspacePrefix: "/tekton/workspaces",
+		},
+		FeatureFlags: &config.FeatureFlags{},
+	})
+	vols := workspace.CreateVolumes(binds)
+	got := resources.ApplyWorkspaces(ctx, spec, decls, binds, vols)
+	want := &v1.TaskSpec{Steps: []v1.Step{{
+		Script: `ls /tekton/workspaces/ws`,
+	}}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("TestApplyWorkspaces_ConfiguredPrefix() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyWorkspaces_IsolatedWorkspaces(t *testing.T) {
 	for _, tc := range []struct {
 		name  string
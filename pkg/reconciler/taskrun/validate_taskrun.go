@@ -33,7 +33,7 @@ import (
 )
 
 // validateParams validates that all Pipeline Task, Matrix.Params and Matrix.Include parameters all have values, match the specified
-// type and object params have all the keys required
+// type, object params have all the keys required and the requires/conflicts constraints declared on the params are satisfied
 func validateParams(ctx context.Context, paramSpecs []v1.ParamSpec, params v1.Params, matrixParams v1.Params) error {
 	if paramSpecs == nil {
 		return nil
@@ -51,6 +51,9 @@ func validateParams(ctx context.Context, paramSpecs []v1.ParamSpec, params v1.Pa
 	if missingKeysObjectParamNames := MissingKeysObjectParamNames(paramSpecs, params); len(missingKeysObjectParamNames) != 0 {
 		return fmt.Errorf("missing keys for these params which are required in ParamSpec's properties %v", missingKeysObjectParamNames)
 	}
+	if err := v1.ValidateParamValues(paramSpecs, providedParams); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -430,6 +430,50 @@ func TestValidateResolvedTask_InvalidParams(t *testing.T) {
 		}},
 		matrix:  &v1.Matrix{},
 		wantErr: "invalid input params for task : missing keys for these params which are required in ParamSpec's properties map[myObjWithoutDefault:[key2]]",
+	}, {
+		name: "required param not provided",
+		task: v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:     "foo",
+					Type:     v1.ParamTypeString,
+					Requires: []string{"bar"},
+				}},
+			},
+		},
+		params: v1.Params{{
+			Name:  "foo",
+			Value: *v1.NewStructuredValues("somethingfun"),
+		}},
+		matrix:  &v1.Matrix{},
+		wantErr: "invalid input params for task : param foo requires bar: params[foo]",
+	}, {
+		name: "conflicting params provided",
+		task: v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:      "foo",
+					Type:      v1.ParamTypeString,
+					Conflicts: []string{"bar"},
+					Default:   v1.NewStructuredValues("foo-default"),
+				}, {
+					Name:    "bar",
+					Type:    v1.ParamTypeString,
+					Default: v1.NewStructuredValues("bar-default"),
+				}},
+			},
+		},
+		params: v1.Params{{
+			Name:  "foo",
+			Value: *v1.NewStructuredValues("somethingfun"),
+		}, {
+			Name:  "bar",
+			Value: *v1.NewStructuredValues("somethingelse"),
+		}},
+		matrix:  &v1.Matrix{},
+		wantErr: "invalid input params for task : param foo conflicts with bar: params[foo]",
 	}}
 	for _, tc := range tcs {
 		rtr := &resources.ResolvedTask{
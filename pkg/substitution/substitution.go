@@ -163,6 +163,7 @@ func ValidateVariableReferenceIsIsolated(value, prefix string, vars sets.String)
 					return &apis.FieldError{
 						Message: fmt.Sprintf("variable is not properly isolated in %q", value),
 						Paths:   paths,
+						Details: fmt.Sprintf("array params must be used as a complete, isolated value like %q", fmt.Sprintf("$(%s.%s[*])", prefix, v)),
 					}
 				}
 			}
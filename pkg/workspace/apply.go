@@ -158,7 +158,7 @@ func Apply(ctx context.Context, ts v1.TaskSpec, wb []v1.WorkspaceBinding, v map[
 
 		volumeMount := corev1.VolumeMount{
 			Name:      vv.Name,
-			MountPath: w.GetMountPath(),
+			MountPath: w.GetMountPathWithContext(ctx),
 			SubPath:   wb[i].SubPath,
 			ReadOnly:  w.ReadOnly,
 		}